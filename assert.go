@@ -2,8 +2,11 @@ package assert
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/nikandfor/assert/deep"
 	"github.com/nikandfor/assert/is"
+	"github.com/nikandfor/assert/source"
 )
 
 type (
@@ -20,6 +23,17 @@ type (
 	}
 
 	wbuf []byte
+
+	argsWriter struct {
+		*wbuf
+
+		t TestingT
+
+		args argNames
+		done bool
+	}
+
+	argNames []source.Arg
 )
 
 func Eval(t TestingT, c Checker, args ...interface{}) (ok bool) {
@@ -27,11 +41,9 @@ func Eval(t TestingT, c Checker, args ...interface{}) (ok bool) {
 		h.Helper()
 	}
 
-	//	fargs := source.AssertionArgs(t)
-
 	var b wbuf
 
-	if c.Check(&b) {
+	if c.Check(&argsWriter{wbuf: &b, t: t}) {
 		return true
 	}
 
@@ -40,45 +52,24 @@ func Eval(t TestingT, c Checker, args ...interface{}) (ok bool) {
 	return false
 }
 
+// Any checks at least one of c passes.
+// It's a shortcut for Eval(t, is.Or(c...)).
 func Any(t TestingT, c []Checker, args ...interface{}) bool {
 	if h, ok := t.(helper); ok {
 		h.Helper()
 	}
 
-	bs := make([]wbuf, len(c))
-
-	for i, c := range c {
-		if c.Check(&bs[i]) {
-			return true
-		}
-	}
-
-	Fail(t, append([]interface{}{bs}, args...)...)
-
-	return false
+	return Eval(t, is.Or(c...), args...)
 }
 
-func All(t TestingT, c []Checker, args ...interface{}) (ok bool) {
+// All checks all of c pass and reports every failure.
+// It's a shortcut for Eval(t, is.AllOf(c...)).
+func All(t TestingT, c []Checker, args ...interface{}) bool {
 	if h, ok := t.(helper); ok {
 		h.Helper()
 	}
 
-	bs := make([]wbuf, len(c))
-
-	ok = true
-
-	for i, c := range c {
-		if !c.Check(&bs[i]) {
-			ok = false
-			break
-		}
-	}
-
-	if !ok {
-		Fail(t, append([]interface{}{bs}, args...)...)
-	}
-
-	return ok
+	return Eval(t, is.AllOf(c...), args...)
 }
 
 func Fail(t TestingT, args ...interface{}) {
@@ -94,11 +85,6 @@ loop:
 		case wbuf:
 			b = append(b, a...)
 			b.Newline()
-		case []wbuf:
-			for _, a := range a {
-				b = append(b, a...)
-				b.Newline()
-			}
 		case string:
 			fmt.Fprintf(&b, a, args[i+1:]...)
 
@@ -186,6 +172,14 @@ func Equal(t TestingT, exp, act interface{}, args ...interface{}) bool {
 	return Eval(t, is.Equal(exp, act), args...)
 }
 
+func EqualWith(t TestingT, exp, act interface{}, opts []deep.Option, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.EqualWith(exp, act, opts...), args...)
+}
+
 func NotEqual(t TestingT, exp, act interface{}, args ...interface{}) bool {
 	if h, ok := t.(helper); ok {
 		h.Helper()
@@ -216,6 +210,55 @@ func (w *wbuf) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+func (w *argsWriter) ArgName(i int) string {
+	return w.load().ArgName(i)
+}
+
+func (w *argsWriter) Nested(i int) is.ArgNamer {
+	return w.load().Nested(i)
+}
+
+// Deadline implements is.Deadliner.
+// It's the test deadline minus deadlineMargin.
+func (w *argsWriter) Deadline() (time.Time, bool) {
+	d, ok := w.t.(is.Deadliner)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	dl, ok := d.Deadline()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return dl.Add(-deadlineMargin), true
+}
+
+func (w *argsWriter) load() argNames {
+	if !w.done {
+		w.args = source.AssertionArgs()
+		w.done = true
+	}
+
+	return w.args
+}
+
+func (a argNames) ArgName(i int) string {
+	if i < len(a) {
+		return a[i].Expr
+	}
+
+	return ""
+}
+
+func (a argNames) Nested(i int) is.ArgNamer {
+	if i < len(a) {
+		return argNames(a[i].Args)
+	}
+
+	return argNames(nil)
+}
+
 func (w *wbuf) Newline() {
 	if l := len(*w); l == 0 || (*w)[l-1] == '\n' {
 		return
@@ -4,19 +4,35 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nikandfor/assert"
+	"github.com/nikandfor/assert/deep"
+	"github.com/nikandfor/assert/is"
 )
 
 type (
 	wbuf []byte
 
+	fakeClock struct {
+		now time.Time
+	}
+
 	TestT struct {
 		testing.T
 
-		failed int
-		b      wbuf
+		failed   int
+		b        wbuf
+		deadline time.Time
+	}
+
+	failCase struct {
+		f   func()
+		exp string
 	}
 )
 
@@ -49,6 +65,174 @@ func TestErrorIs(t *testing.T) {
 	checkFailed(t, tt, 1)
 }
 
+func TestErrorMatching(t *testing.T) {
+	tt := &TestT{}
+
+	perr := &os.PathError{Op: "open", Path: "file", Err: io.ErrUnexpectedEOF}
+	err := fmt.Errorf("wrapped: %w", errors.Join(io.EOF, perr))
+
+	var target *os.PathError
+
+	assert.ErrorIs(tt, err, io.ErrUnexpectedEOF)
+	assert.ErrorAs(tt, err, &target)
+	assert.ErrorContains(tt, err, "open file")
+	assert.ErrorRegexp(tt, err, `^wrapped: EOF\n`)
+	assert.ErrorType(tt, err, perr)
+	checkOK(t, tt)
+
+	assert.ErrorType(tt, err, &os.LinkError{})
+	checkFailed(t, tt, 1)
+
+	exp := `Error chain
+"wrapped: EOF\nopen file: unexpected EOF" (type *fmt.wrapError)
+"EOF\nopen file: unexpected EOF" (type *errors.joinError)
+    "EOF" (type *errors.errorString)
+    "open file: unexpected EOF" (type *fs.PathError)
+    "unexpected EOF" (type *errors.errorString)
+has no error of type *os.LinkError
+`
+
+	if string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	var bad string
+
+	assert.ErrorAs(tt, err, &bad)
+	checkFailed(t, tt, 1)
+
+	if exp := "Target must be a pointer to an interface or to a type implementing error, got: *string\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	tt := &TestT{}
+
+	assert.Panics(tt, func() { panic("boom") })
+	assert.PanicsWithValue(tt, func() { panic([]int{1, 2}) }, []int{1, 2})
+	assert.PanicsWithError(tt, func() { panic(fmt.Errorf("wrapped: %w", io.EOF)) }, io.EOF)
+	assert.NotPanics(tt, func() {})
+	checkOK(t, tt)
+
+	assert.NotPanics(tt, func() { panic("boom") })
+	checkFailed(t, tt, 1)
+
+	if !strings.HasPrefix(string(tt.b), "PANIC: boom\ngoroutine ") || !strings.Contains(string(tt.b), "TestPanics.func") || strings.Contains(string(tt.b), "runtime/debug") {
+		t.Errorf("bad output:\n%s", tt.b)
+	}
+
+	tt.reset()
+
+	assert.Panics(tt, func() {})
+	checkFailed(t, tt, 1)
+
+	tt.reset()
+
+	assert.PanicsWithError(tt, func() { panic(io.EOF) }, nil)
+	checkFailed(t, tt, 1)
+
+	if exp := "Target error must not be nil\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	assert.PanicsWithValue(tt, func() {}, "boom")
+	checkFailed(t, tt, 1)
+
+	if exp := "Want panic with value: \"boom\"\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+}
+
+func TestEventually(t *testing.T) {
+	tt := &TestT{}
+
+	n := 0
+	assert.Eventually(tt, func() bool { n++; return n == 3 }, time.Second, time.Millisecond)
+	checkOK(t, tt)
+
+	clock := &fakeClock{}
+
+	n = 0
+	assert.Eval(tt, is.Eventually(func() is.Checker { n++; return is.Equal(20, n) }, time.Second, 100*time.Millisecond).WithClock(clock))
+	checkFailed(t, tt, 1)
+
+	if !strings.HasPrefix(string(tt.b), "Condition not met in 1s (11 attempts), last failure:\nNot equal:\n") {
+		t.Errorf("bad output:\n%s", tt.b)
+	}
+
+	tt.reset()
+
+	n = 0
+	assert.Eval(tt, is.Never(func() is.Checker { n++; return is.True(n > 3) }, time.Second, 100*time.Millisecond).WithClock(clock))
+	checkFailed(t, tt, 1)
+
+	if exp := "Condition passed after 300ms (attempt 4)\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	assert.Eval(tt, is.Consistently(func() is.Checker { return is.NoError(nil) }, time.Second, 100*time.Millisecond).WithClock(clock))
+	checkOK(t, tt)
+
+	assert.Eval(tt, is.Consistently(func() is.Checker { return is.NoError(nil) }, time.Second, 100*time.Millisecond).WithClock(clock).WithDeadline(clock.now.Add(500*time.Millisecond)))
+	checkFailed(t, tt, 1)
+
+	if exp := "Stopped at the test deadline after 500ms of 1s (6 attempts)\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+	tt.deadline = clock.now.Add(time.Second + 300*time.Millisecond)
+
+	assert.Eval(tt, is.Or(is.Consistently(func() is.Checker { return is.NoError(nil) }, time.Second, 100*time.Millisecond).WithClock(clock)))
+	checkFailed(t, tt, 1)
+
+	if exp := "Stopped at the test deadline after 300ms of 1s (4 attempts)\n"; !strings.Contains(string(tt.b), exp) {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+}
+
+func TestInDelta(t *testing.T) {
+	tt := &TestT{}
+
+	assert.InDelta(tt, 0.3, 0.1+0.2, 1e-9)
+	assert.InDelta(tt, 5, int64(6), 1)
+	assert.InEpsilon(tt, 100, 101, 0.02)
+	assert.InDeltaSlice(tt, []float64{1, 2}, []float32{1.05, 2}, 0.1)
+	assert.InDeltaMapValues(tt, map[string]float64{"a": 1}, map[string]float64{"a": 1.01}, 0.1)
+	assert.InDelta(tt, math.NaN(), math.NaN(), 0)
+	assert.InEpsilon(tt, math.NaN(), math.NaN(), 0)
+	checkOK(t, tt)
+
+	assert.InDelta(tt, math.Inf(1), math.Inf(-1), math.Inf(1))
+	checkFailed(t, tt, 1)
+
+	tt.reset()
+
+	assert.InEpsilon(tt, math.Inf(1), 1, 0.5)
+	checkFailed(t, tt, 1)
+
+	tt.reset()
+
+	assert.InDeltaMapValues(tt, map[string]float64{"a": 1, "b": 2}, map[string]float64{"a": 2, "c": 3}, 0.1)
+	checkFailed(t, tt, 1)
+
+	exp := `["a"]: |1 - 2| > 0.1 (delta 1)
+["b"]: missing in actual
+["c"]: extra in actual
+`
+
+	if string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+}
+
 func TestEqual(t *testing.T) {
 	tt := &TestT{}
 
@@ -57,6 +241,407 @@ func TestEqual(t *testing.T) {
 
 	assert.Equal(tt, "asd", "qwe")
 	checkFailed(t, tt, 1)
+
+	tt.reset()
+
+	assert.EqualWith(tt, time.Second, 2*time.Second, []deep.Option{deep.UseStringer()})
+	checkFailed(t, tt, 1)
+
+	if !strings.Contains(string(tt.b), `Expected (time.Second): time.Duration("1s")`) {
+		t.Errorf("printing options are not used: %s", tt.b)
+	}
+}
+
+func TestSourceArgs(t *testing.T) {
+	tt := &TestT{}
+
+	name := "alice"
+
+	assert.Equal(tt, "bob", name)
+	checkFailed(t, tt, 1)
+
+	if !strings.Contains(string(tt.b), "Expected: ") || !strings.Contains(string(tt.b), "Actual (name): ") {
+		t.Errorf("no source args:\n%s", tt.b)
+	}
+
+	tt.reset()
+
+	assert.Eval(tt, is.True(len(name) > 10))
+	checkFailed(t, tt, 1)
+
+	if !strings.Contains(string(tt.b), "Want true (len(name) > 10)") {
+		t.Errorf("no source args:\n%s", tt.b)
+	}
+
+	tt.reset()
+
+	other := "bob"
+
+	_ = assert.True(tt, len(name) > 10) || assert.True(tt, len(other) > 10)
+	checkFailed(t, tt, 1)
+
+	if strings.Contains(string(tt.b), "(len(name) > 10)") || strings.Contains(string(tt.b), "(len(other) > 10)") {
+		t.Errorf("ambiguous source args:\n%s", tt.b)
+	}
+
+	tt.reset()
+
+	c := is.Equal("bob", name)
+	assert.Eval(tt, c)
+	checkFailed(t, tt, 1)
+
+	if strings.Contains(string(tt.b), "(c)") || strings.Contains(string(tt.b), "(name)") {
+		t.Errorf("source args of a checker variable:\n%s", tt.b)
+	}
+}
+
+func TestEqualOf(t *testing.T) {
+	tt := &TestT{}
+
+	var x int64 = 5
+
+	assert.EqualOf(tt, 5, x)
+	checkOK(t, tt)
+
+	assert.EqualOf(tt, 6, x)
+	checkFailed(t, tt, 1)
+}
+
+func TestOrderedOf(t *testing.T) {
+	tt := &TestT{}
+
+	assert.LessOf(tt, 1, 2)
+	assert.GreaterOf(tt, "b", "a")
+	assert.InRangeOf(tt, 1.5, 1, 2)
+	checkOK(t, tt)
+
+	assert.InRangeOf(tt, 3, 1, 2)
+	checkFailed(t, tt, 1)
+
+	if exp := "3 is not in [1, 2] (above by 1)\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	assert.LessOrEqualOf(tt, 2, 2)
+	assert.GreaterOrEqualOf(tt, 3, 2)
+	assert.PositiveOf(tt, 0.1)
+	assert.NegativeOf(tt, -1)
+	checkOK(t, tt)
+}
+
+func TestOrder(t *testing.T) {
+	tt := &TestT{}
+
+	var x int64 = 5
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Greater(tt, x, 3)
+	assert.Greater(tt, uint64(1<<63), -1)
+	assert.GreaterOrEqual(tt, 2.5, 2)
+	assert.Less(tt, "abc", "abd")
+	assert.LessOrEqual(tt, time.Second, time.Second)
+	assert.Less(tt, now, now.Add(time.Hour))
+	assert.Between(tt, 1.5, 1, 2)
+	assert.Positive(tt, time.Minute)
+	assert.Negative(tt, int8(-1))
+	assert.Finite(tt, 1e300)
+	assert.NaN(tt, math.NaN())
+	checkOK(t, tt)
+
+	checkFailures(t, tt, []failCase{
+		{func() { assert.Less(tt, 5, 3) }, `5 is not < 3 (by 2)`},
+		{func() { assert.Greater(tt, x, 7) }, `5 is not > 7 (by 2)`},
+		{func() { assert.LessOrEqual(tt, 2*time.Second, 500*time.Millisecond) }, `2s is not <= 500ms (by 1.5s)`},
+		{func() { assert.GreaterOrEqual(tt, now, now.Add(time.Minute)) }, `2024-01-01T00:00:00Z is not >= 2024-01-01T00:01:00Z (by 1m0s)`},
+		{func() { assert.Less(tt, math.NaN(), 1.0) }, `NaN is not < 1`},
+		{func() { assert.Greater(tt, "a", "b") }, `"a" is not > "b"`},
+		{func() { assert.Between(tt, 7, 1, 5) }, `7 is not in [1, 5] (above by 2)`},
+		{func() { assert.Between(tt, 3, 5, 1) }, `Bad range: 5 > 1`},
+		{func() { assert.Positive(tt, -0.5) }, `-0.5 is not > 0 (by 0.5)`},
+		{func() { assert.Finite(tt, math.Inf(-1)) }, `Want finite number, got: -Inf`},
+		{func() { assert.Less(tt, "a", 1) }, `Can't compare string and int`},
+	})
+}
+
+func TestCollections(t *testing.T) {
+	type str string
+
+	tt := &TestT{}
+
+	assert.Contains(tt, "abcd", "bc")
+	assert.Contains(tt, str("abcd"), str("bc"))
+	assert.Contains(tt, []int{1, 2, 3}, 2)
+	assert.Contains(tt, map[string]int{"a": 1}, "a")
+	assert.NotContains(tt, []string{"a"}, "b")
+	assert.Len(tt, []int{1, 2}, 2)
+	assert.Empty(tt, map[int]int{})
+	assert.NotEmpty(tt, "a")
+	assert.ElementsMatch(tt, []int{1, 2, 2, 3}, []int{2, 3, 2, 1})
+	assert.Subset(tt, []int{1, 2, 3}, []int{3, 1})
+	assert.Subset(tt, map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2})
+	checkOK(t, tt)
+
+	assert.ElementsMatch(tt, []int{1, 2, 2, 3}, []int{2, 3, 4, 1})
+	checkFailed(t, tt, 1)
+
+	if exp := "Elements do not match:\n[2]: missing in actual: int(0x2)\n[2]: extra in actual: int(0x4)\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	assert.Subset(tt, []int{1, 2, 3}, []int{4, 1})
+	checkFailed(t, tt, 1)
+
+	tt.reset()
+
+	assert.Subset(tt, map[string]int{"a": 1}, map[int]int{1: 1})
+	checkFailed(t, tt, 1)
+
+	if exp := "Can't check map[int]int is subset of map[string]int\n"; string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+}
+
+func TestJSONEq(t *testing.T) {
+	tt := &TestT{}
+
+	type item struct {
+		Name string `json:"name"`
+		N    int    `json:"n"`
+	}
+
+	assert.JSONEq(tt, `{"a": 1, "b": [true, null]}`, []byte(`{"b":[true,null],"a":1.0}`))
+	assert.JSONEq(tt, `[{"name": "x", "n": 2}]`, []item{{Name: "x", N: 2}})
+	assert.JSONEq(tt, `12345678901234567890`, strings.NewReader(`1.2345678901234567890e19`))
+	assert.Eval(tt, is.JSONEq(`{"f": 0.3}`, `{"f": 0.30001}`, deep.FloatTolerance(1e-3, 0)))
+	checkOK(t, tt)
+
+	assert.JSONEq(tt, `{"items": [{"name": "a"}, 1, 2, 3], "a/b": 1, "x": 1}`, `{"items": [{"name": "b"}, 1, 2], "a/b": "1", "y": 1}`)
+	checkFailed(t, tt, 1)
+
+	exp := `JSON not equal:
+/a~1b: 1 != "1"
+/items/0/name: "a" != "b"
+/items/3: missing in actual: 3
+/x: missing in actual: 1
+/y: extra in actual: 1
+`
+
+	if string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	assert.JSONEq(tt, `{}`, `{"a": 1} x`)
+	checkFailed(t, tt, 1)
+}
+
+func TestXMLEq(t *testing.T) {
+	tt := &TestT{}
+
+	assert.XMLEq(tt, `<feed xmlns="http://www.w3.org/2005/Atom"><entry a="1" b="2">text</entry></feed>`,
+		`<?xml version="1.0"?>
+<f:feed xmlns:f="http://www.w3.org/2005/Atom">
+	<!-- comment -->
+	<f:entry b="2" a="1">
+		text
+	</f:entry>
+</f:feed>`)
+	checkOK(t, tt)
+
+	assert.XMLEq(tt, `<feed><title>a</title><entry href="x"/><entry href="y" rel="alt"/><id/></feed>`,
+		`<feed><title>b</title><entry href="x"/><entry href="z" type="t"/><link/><id/></feed>`)
+	checkFailed(t, tt, 1)
+
+	exp := `XML not equal:
+/feed/title/text(): "a" != "b"
+/feed/entry[2]/@href: "y" != "z"
+/feed/entry[2]/@rel: missing in actual: "alt"
+/feed/entry[2]/@type: extra in actual: "t"
+/feed/*[4]: element id != link
+/feed/id: extra in actual: id
+`
+
+	if string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+
+	tt.reset()
+
+	assert.XMLEq(tt, `<a/>`, `<a></b>`)
+	checkFailed(t, tt, 1)
+}
+
+func TestStrings(t *testing.T) {
+	tt := &TestT{}
+
+	assert.HasPrefix(tt, "prefix_string", "prefix")
+	assert.HasSuffix(tt, "string_suffix", "suffix")
+	assert.EqualFold(tt, "Straße", "strAße")
+	assert.ContainsString(tt, "some long string", "long")
+	assert.Regexp(tt, `^\w+@\w+\.com$`, "user@host.com")
+	assert.NotRegexp(tt, `\d`, "no digits")
+	checkOK(t, tt)
+
+	checkFailures(t, tt, []failCase{
+		{func() { assert.HasPrefix(tt, "prefiks", "prefix") }, `Want prefix:
+prefix: "prefix"
+string: "prefiks"
+              ^ at rune 5`},
+		{func() { assert.HasSuffix(tt, "ещё суфикс", "суффикс") }, `Want suffix:
+suffix:    "суффикс"
+string: "ещё суфикс"
+              ^ at rune 5`},
+		{func() { assert.HasSuffix(tt, "abcdef", "xef") }, `Want suffix:
+suffix:    "xef"
+string: "abcdef"
+            ^ at rune 3`},
+		{func() { assert.EqualFold(tt, "Hello", "HELP") }, `Not equal ignoring case:
+expected: "Hello"
+actual:   "HELP"
+              ^ at rune 3`},
+		{func() { assert.ContainsString(tt, "a quick brown fox", "quiet") }, `Want substring:
+substr:   "quiet"
+string: "a quick brown fox"
+              ^ partial match "qui" at rune 2`},
+		{func() { assert.Regexp(tt, `fo+ \d+`, "xx foo bar") }, `String does not match "fo+ \\d+":
+string: "xx foo bar"
+                ^ partial match "foo " at rune 3`},
+		{func() { assert.NotRegexp(tt, `\d+`, "abc 123") }, `String matches "\\d+":
+string: "abc 123"
+             ^ match "123" at rune 4`},
+	})
+}
+
+// checkFailures runs each case expecting it to fail with the exact output.
+func checkFailures(t *testing.T, tt *TestT, cases []failCase) {
+	for _, tc := range cases {
+		tt.reset()
+
+		tc.f()
+		checkFailed(t, tt, 1)
+
+		if string(tt.b) != tc.exp+"\n" {
+			t.Errorf("output:\n%s\nexpected:\n%s", tt.b, tc.exp)
+		}
+	}
+}
+
+func TestTime(t *testing.T) {
+	tt := &TestT{}
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(1500 * time.Millisecond)
+
+	assert.WithinDuration(tt, now, later, 2*time.Second)
+	assert.SameInstant(tt, now, now.In(time.FixedZone("X", 3600)))
+	mono := time.Now()
+
+	assert.SameInstant(tt, mono, mono.Round(0))
+	assert.Equal(tt, mono, mono.Round(0))
+	assert.TimeBefore(tt, now, later)
+	assert.TimeAfter(tt, later, now)
+	assert.Truncated(tt, now, time.Hour)
+	assert.Equal(tt, now, now.In(time.FixedZone("X", 3600)))
+	checkOK(t, tt)
+
+	checkFailures(t, tt, []failCase{
+		{func() { assert.WithinDuration(tt, now, later, time.Second) }, `Want times within 1s, got delta 1.5s:
+expected: 2024-03-01T12:00:00Z
+actual:   2024-03-01T12:00:01.5Z`},
+		{func() { assert.SameInstant(tt, now, later.In(time.FixedZone("X", 3600))) }, `Want the same instant, got delta 1.5s:
+expected: 2024-03-01T12:00:00Z
+actual:   2024-03-01T13:00:01.5+01:00`},
+		{func() { assert.TimeBefore(tt, later, now) }, `Want time before reference, got 1.5s after:
+time:      2024-03-01T12:00:01.5Z
+reference: 2024-03-01T12:00:00Z`},
+		{func() { assert.Truncated(tt, later, time.Second) }, `Want time truncated to 1s, got 500ms extra:
+time:      2024-03-01T12:00:01.5Z
+truncated: 2024-03-01T12:00:01Z`},
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	tt := &TestT{}
+
+	var err error
+
+	name, n := "short", 1
+
+	assert.Eval(tt, is.Not(is.Nil(io.EOF)))
+	assert.Eval(tt, is.And(is.True(true), is.Equal(1, 1)))
+	assert.Eval(tt, is.Or(is.Equal(1, 2), is.Equal(1, 1)))
+	assert.Eval(tt, is.AnyOf(is.Error(err), is.NoError(err)))
+	assert.Eval(tt, is.NoneOf(is.Error(err), is.HasPrefix("abc", "b")))
+	assert.All(tt, []assert.Checker{is.True(true), is.Not(is.Not(is.True(true)))})
+	checkOK(t, tt)
+
+	checkFailures(t, tt, []failCase{
+		{func() { assert.Eval(tt, is.Not(is.Equal(1, 1))) }, `Want not equal to int(0x1)`},
+		{func() { assert.Eval(tt, is.Not(is.Not(is.Nil(io.EOF)))) }, `Want not not nil`},
+		{func() { assert.Eval(tt, is.Not(is.EqualOf(1, 1))) }, `Want not equal to int(0x1)`},
+		{func() { assert.Eval(tt, is.Not(is.CheckerFunc(func(io.Writer) bool { return true }))) }, `Want check to fail, but it passed`},
+		{func() { assert.Eval(tt, is.Not(is.NotEqual(1, 2))) }, `Want not not equal to int(0x1)`},
+		{func() { assert.Eval(tt, is.Not(is.ErrorContains(io.EOF, "EO"))) }, `Want not error containing "EO"`},
+		{func() { assert.Eval(tt, is.Not(is.Subset([]int{1, 2}, []int{2}))) }, `Want not superset of []int{2}`},
+		{func() { assert.Eval(tt, is.Not(is.NotPanics(func() {}))) }, `Want not no panic`},
+		{func() { assert.Eval(tt, is.Not(is.InDelta(1.0, 1.05, 0.1))) }, `Want not within 0.1 of 1`},
+		{func() { assert.Eval(tt, is.Not(is.InEpsilon(100, 101, 0.02))) }, `Want not within relative error 0.02 of 100`},
+		{func() { assert.Eval(tt, is.Not(is.InDeltaSlice([]float64{1}, []float64{1}, 0.1))) }, `Want not elements within 0.1 of []float64{float64(1)}`},
+		{func() {
+			assert.Eval(tt, is.Not(is.InDeltaMapValues(map[string]float64{"a": 1}, map[string]float64{"a": 1}, 0.1)))
+		}, `Want not values within 0.1 of map[string]float64{
+    "a": float64(1)
+}`},
+		{func() { assert.Eval(tt, is.Not(is.Greater(2, 1))) }, `Want not > 1`},
+		{func() { assert.Eval(tt, is.Not(is.GreaterOrEqual(1, 1))) }, `Want not >= 1`},
+		{func() { assert.Eval(tt, is.Not(is.Less(time.Second, time.Minute))) }, `Want not < 1m0s`},
+		{func() { assert.Eval(tt, is.Not(is.LessOrEqual(1, 1))) }, `Want not <= 1`},
+		{func() { assert.Eval(tt, is.Not(is.LessOf(1, 2))) }, `Want not < 2`},
+		{func() { assert.Eval(tt, is.Not(is.Between(2, 1, 3))) }, `Want not in [1, 3]`},
+		{func() { assert.Eval(tt, is.Not(is.InRangeOf(2, 1, 3))) }, `Want not in [1, 3]`},
+		{func() { assert.Eval(tt, is.Not(is.Finite(1.5))) }, `Want not finite number`},
+		{func() { assert.Eval(tt, is.Not(is.NaN(math.NaN()))) }, `Want not NaN`},
+		{func() { assert.Eval(tt, is.Not(is.JSONEq(`{"a":1}`, `{"a": 1}`))) }, `Want not JSON equal to {"a":1}`},
+		{func() { assert.Eval(tt, is.Not(is.XMLEq(`<a/>`, `<a></a>`))) }, `Want not XML equal to <a/>`},
+		{func() {
+			assert.Eval(tt, is.Not(is.Eventually(func() is.Checker { return is.True(true) }, time.Second, time.Millisecond)))
+		}, `Want not condition eventually passing within 1s`},
+		{func() { assert.Eval(tt, is.And(is.True(true), is.False(true), is.Nil(1))) }, `Check failed:
+    [1] Want false`},
+		{func() {
+			assert.Any(tt, []assert.Checker{is.Nil(1), is.AllOf(is.True(false), is.Len("ab", 3), is.True(true))})
+		}, `None of 2 checks passed:
+    [0] Want nil, got: 1
+    [1] 2 of 3 checks failed:
+            [0] Want true
+            [1] Want len 3, got 2: "ab"`},
+		{func() {
+			assert.Eval(tt, is.NoneOf(is.Error(io.EOF), is.HasPrefix("abc", "a"), is.ErrorIs(io.EOF, io.EOF)))
+		}, `3 of 3 checks passed:
+    [0] Want not error
+    [1] Want not prefix "a"
+    [2] Want not error is "EOF"`},
+		{func() { assert.Eval(tt, is.Or(is.True(len(name) > 10), is.And(is.Nil(n)))) }, `None of 2 checks passed:
+    [0] Want true (len(name) > 10)
+    [1] Check failed:
+            [0] Want nil (n), got: 1`},
+		{func() { assert.Any(tt, []assert.Checker{is.Nil(n), is.Zero(n)}) }, `None of 2 checks passed:
+    [0] Want nil (n), got: 1
+    [1] Want zero value, got: 1`},
+		{func() { assert.Eval(tt, is.AllOf(is.Equal(1, 2), is.True(false))) }, `2 of 2 checks failed:
+    [0] Not equal:
+        Expected: int(0x1)
+        Actual:   int(0x2)
+        Diff:
+        1 != 2
+    [1] Want true`},
+	})
 }
 
 func checkOK(t *testing.T, tt *TestT) {
@@ -81,6 +666,14 @@ func failTT(t *testing.T, tt *TestT) {
 	t.Errorf("failed: %v\n%s", tt.failed, tt.b)
 }
 
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+func (tt *TestT) Deadline() (time.Time, bool) {
+	return tt.deadline, !tt.deadline.IsZero()
+}
+
 func (tt *TestT) Fail() {
 	tt.failed = 1
 }
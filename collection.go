@@ -0,0 +1,59 @@
+package assert
+
+import "github.com/nikandfor/assert/is"
+
+func Contains(t TestingT, x, elem interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Contains(x, elem), args...)
+}
+
+func NotContains(t TestingT, x, elem interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.NotContains(x, elem), args...)
+}
+
+func Len(t TestingT, x interface{}, l int, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Len(x, l), args...)
+}
+
+func Empty(t TestingT, x interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Empty(x), args...)
+}
+
+func NotEmpty(t TestingT, x interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.NotEmpty(x), args...)
+}
+
+func ElementsMatch(t TestingT, exp, act interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.ElementsMatch(exp, act), args...)
+}
+
+func Subset(t TestingT, list, sub interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Subset(list, sub), args...)
+}
@@ -4,18 +4,19 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
-	"math/big"
-	"os"
+	"math"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
 	"unsafe"
 )
 
 type (
-	prefixWriter struct {
+	// PrefixWriter writes prefix at the beginning of each line.
+	PrefixWriter struct {
 		io.Writer
 		pref []byte
 		add  bool
@@ -57,43 +58,63 @@ type (
 		flag uintptr
 	}
 
+	comparer struct {
+		config
+
+		w       io.Writer
+		path    []byte
+		visited map[visit]struct{}
+	}
+
 	formatter struct {
 		io.Writer
+		config
+
+		refs   map[refKey]*ref
+		labels int
+
 		notnl bool
 	}
 )
 
 var spaces = "                                                                          "
 
-var stop = map[reflect.Type]struct{}{
-	reflect.TypeOf(time.Time{}):      {},
-	reflect.TypeOf(&time.Location{}): {},
-	reflect.TypeOf(&big.Int{}):       {},
-	reflect.TypeOf(&os.File{}):       {},
-}
-
 func Equal(a, b interface{}) bool {
-	av := reflect.ValueOf(a)
-	bv := reflect.ValueOf(b)
-
-	return equal(av, bv, nil)
+	return EqualWith(a, b)
 }
 
 func Diff(w io.Writer, a, b interface{}) bool {
-	av := reflect.ValueOf(a)
-	bv := reflect.ValueOf(b)
-
-	return equal(av, bv, nil)
+	return DiffWith(w, a, b)
 }
 
-func equal(a, b reflect.Value, visited map[visit]struct{}) bool {
+func (c *comparer) equal(a, b reflect.Value) bool {
 	if !a.IsValid() || !b.IsValid() {
-		return a.IsValid() == b.IsValid()
+		if a.IsValid() == b.IsValid() {
+			return true
+		}
+
+		c.difft(a, b)
+
+		return false
 	}
 	if a.Type() != b.Type() {
+		c.difft(a, b)
+
 		return false
 	}
 
+	if f, ok := c.comparers[a.Type()]; ok {
+		if eq, ok := c.custom(f, a, b); ok {
+			return eq
+		}
+	}
+
+	if !c.noMethods {
+		if eq, ok := c.equalMethod(a, b); ok {
+			return eq
+		}
+	}
+
 	// The hard part is taken from reflect.DeepEqual
 
 	// We want to avoid putting more in the visited map than we need to.
@@ -146,20 +167,23 @@ func equal(a, b reflect.Value, visited map[visit]struct{}) bool {
 		// Short circuit if references are already seen.
 		typ := a.Type()
 		v := visit{a: addr1, b: addr2, typ: typ}
-		if _, ok := visited[v]; ok {
+		if _, ok := c.visited[v]; ok {
 			return true
 		}
 
-		if visited == nil {
-			visited = make(map[visit]struct{})
+		if c.visited == nil {
+			c.visited = make(map[visit]struct{})
 		}
 
 		// Remember for later.
-		visited[v] = struct{}{}
+		c.visited[v] = struct{}{}
 	}
 
-	for a.Kind() == reflect.Ptr {
+	switch a.Kind() {
+	case reflect.Ptr:
 		if a.IsNil() != b.IsNil() {
+			c.diff(a, b)
+
 			return false
 		}
 
@@ -167,50 +191,72 @@ func equal(a, b reflect.Value, visited map[visit]struct{}) bool {
 			return true
 		}
 
-		a = a.Elem()
-		b = b.Elem()
-	}
+		return c.equal(a.Elem(), b.Elem())
 
-	switch a.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 		reflect.Uintptr, reflect.UnsafePointer,
-		reflect.Float64, reflect.Float32,
-		reflect.Complex128, reflect.Complex64,
-		reflect.String,
 		reflect.Chan,
 		reflect.Bool:
 
-		return eface(a) == eface(b)
+		if eface(a) == eface(b) {
+			return true
+		}
 
-	case reflect.Interface:
-		ai := a.InterfaceData()
-		bi := b.InterfaceData()
+		c.diff(a, b)
 
-		if ai[0] != bi[0] {
-			return false
+		return false
+
+	case reflect.Float64, reflect.Float32:
+		if c.floatEqual(a.Float(), b.Float()) {
+			return true
+		}
+
+		c.diffLine("%s != %s (delta %v)", short(a), short(b), math.Abs(a.Float()-b.Float()))
+
+		return false
+
+	case reflect.Complex128, reflect.Complex64:
+		if c.complexEqual(a.Complex(), b.Complex()) {
+			return true
+		}
+
+		c.diffLine("%s != %s (delta %v)", short(a), short(b), cmplxAbs(a.Complex()-b.Complex()))
+
+		return false
+
+	case reflect.String:
+		if a.String() == b.String() {
+			return true
 		}
 
-		return equal(a.Elem(), b.Elem(), visited)
+		c.diffText(a.String(), b.String())
+
+		return false
+
+	case reflect.Interface:
+		return c.equal(a.Elem(), b.Elem())
+
 	case reflect.Slice, reflect.Array:
-		return equalSlice(a, b, visited)
+		return c.equalSlice(a, b)
 
 	case reflect.Struct:
-		return equalStructFields(a, b, visited)
+		return c.equalStructFields(a, b)
 
 	case reflect.Map:
-		return equalMap(a, b, visited)
+		return c.equalMap(a, b)
 
 	case reflect.Func:
-		return equalFunc(a, b, visited)
+		return c.equalFunc(a, b)
 
 	default:
 		panic(fmt.Sprintf("cannot compare %v", a.Kind()))
 	}
 }
 
-func equalStructFields(a, b reflect.Value, visited map[visit]struct{}) bool {
+func (c *comparer) equalStructFields(a, b reflect.Value) (eq bool) {
 	t := a.Type()
+	eq = true
 
 	for i := 0; i < t.NumField(); i++ {
 		ft := t.Field(i)
@@ -218,65 +264,253 @@ func equalStructFields(a, b reflect.Value, visited map[visit]struct{}) bool {
 			continue
 		}
 
-		f, ok := getTag(ft, "deep", "compare")
-		switch {
-		case ok && f == "false":
+		if c.ignoreUnexported && !ft.IsExported() {
 			continue
-		case ok && (f == "nil" || f == "isnil"):
-			if a.Field(i).IsNil() != b.Field(i).IsNil() {
-				return false
-			}
+		}
+
+		l := len(c.path)
+		c.path = append(c.path, '.')
+		c.path = append(c.path, ft.Name...)
 
+		if c.ignored(c.path) {
+			c.path = c.path[:l]
 			continue
-		case ok && (f == "pointer" || f == "ptr"):
+		}
+
+		ok := true
+
+		f, tagged := getTag(ft, "deep", "compare")
+		switch {
+		case tagged && f == "false":
+		case tagged && (f == "nil" || f == "isnil"):
+			if a.Field(i).IsNil() != b.Field(i).IsNil() {
+				c.diff(a.Field(i), b.Field(i))
+				ok = false
+			}
+		case tagged && (f == "pointer" || f == "ptr"):
 			if a.Field(i).Pointer() != b.Field(i).Pointer() {
+				c.diffPointer(a.Field(i), b.Field(i))
+				ok = false
+			}
+		default:
+			ok = c.equal(a.Field(i), b.Field(i))
+		}
+
+		c.path = c.path[:l]
+
+		if !ok {
+			eq = false
+
+			if c.w == nil {
 				return false
 			}
+		}
+	}
 
-			continue
+	return eq
+}
+
+func (c *comparer) equalSlice(a, b reflect.Value) (eq bool) {
+	if a.Kind() == reflect.Slice && c.strictNil && a.IsNil() != b.IsNil() {
+		c.diff(a, b)
+
+		return false
+	}
+
+	if a.Type().Elem().Kind() == reflect.Uint8 {
+		return c.equalBytes(a, b)
+	}
+
+	if c.ignoreOrder {
+		return c.equalUnordered(a, b)
+	}
+
+	if a.Len() != b.Len() && c.w == nil {
+		return false
+	}
+
+	eq = a.Len() == b.Len()
+
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		l := len(c.path)
+		c.path = append(c.path, '[')
+		c.path = strconv.AppendInt(c.path, int64(i), 10)
+		c.path = append(c.path, ']')
+
+		switch {
+		case i >= b.Len():
+			c.diffMissing(a.Index(i))
+		case i >= a.Len():
+			c.diffExtra(b.Index(i))
+		case !c.equal(a.Index(i), b.Index(i)):
+			eq = false
 		}
 
-		if !equal(a.Field(i), b.Field(i), visited) {
+		c.path = c.path[:l]
+
+		if !eq && c.w == nil {
 			return false
 		}
 	}
 
-	return true
+	return eq
 }
 
-func equalSlice(a, b reflect.Value, visited map[visit]struct{}) bool {
-	if a.Len() != b.Len() {
+func (c *comparer) equalMap(a, b reflect.Value) (eq bool) {
+	if c.strictNil && a.IsNil() != b.IsNil() {
+		c.diff(a, b)
+
 		return false
 	}
 
-	for i := 0; i < a.Len(); i++ {
-		if !equal(a.Index(i), b.Index(i), visited) {
+	if c.w == nil {
+		if a.Len() != b.Len() {
 			return false
 		}
+
+		it := a.MapRange()
+
+		for it.Next() {
+			l := len(c.path)
+			c.path = appendMapKey(c.path, it.Key())
+
+			ok := c.equal(it.Value(), b.MapIndex(it.Key()))
+
+			c.path = c.path[:l]
+
+			if !ok {
+				return false
+			}
+		}
+
+		return true
 	}
 
-	return true
+	eq = true
+
+	keys := a.MapKeys()
+
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+
+	sortKeys(keys)
+
+	for _, k := range keys {
+		l := len(c.path)
+		c.path = appendMapKey(c.path, k)
+
+		av := a.MapIndex(k)
+		bv := b.MapIndex(k)
+
+		switch {
+		case !bv.IsValid():
+			c.diffMissing(av)
+			eq = false
+		case !av.IsValid():
+			c.diffExtra(bv)
+			eq = false
+		case !c.equal(av, bv):
+			eq = false
+		}
+
+		c.path = c.path[:l]
+
+		if !eq && c.w == nil {
+			return false
+		}
+	}
+
+	return eq
+}
+
+func appendMapKey(p []byte, k reflect.Value) []byte {
+	p = append(p, '[')
+	p = append(p, fmt.Sprintf("%#v", k)...)
+
+	return append(p, ']')
 }
 
-func equalMap(a, b reflect.Value, visited map[visit]struct{}) bool {
-	if a.Len() != b.Len() {
+func (c *comparer) equalUnordered(a, b reflect.Value) (eq bool) {
+	if a.Len() != b.Len() && c.w == nil {
 		return false
 	}
 
-	it := a.MapRange()
+	used := make([]bool, b.Len())
+	var missing []int
 
-	for it.Next() {
-		v := b.MapIndex(it.Key())
+	w, visited := c.w, c.visited
+	c.w = nil
 
-		if !equal(it.Value(), v, visited) {
-			return false
+outer:
+	for i := 0; i < a.Len(); i++ {
+		l := len(c.path)
+		c.path = append(c.path, '[')
+		c.path = strconv.AppendInt(c.path, int64(i), 10)
+		c.path = append(c.path, ']')
+
+		for j := 0; j < b.Len(); j++ {
+			if used[j] {
+				continue
+			}
+
+			c.visited = nil
+
+			if c.equal(a.Index(i), b.Index(j)) {
+				used[j] = true
+				c.path = c.path[:l]
+
+				continue outer
+			}
+		}
+
+		c.path = c.path[:l]
+
+		missing = append(missing, i)
+
+		if w == nil {
+			break
 		}
 	}
 
-	return true
+	c.w, c.visited = w, visited
+
+	eq = len(missing) == 0
+
+	for _, i := range missing {
+		l := len(c.path)
+		c.path = append(c.path, '[')
+		c.path = strconv.AppendInt(c.path, int64(i), 10)
+		c.path = append(c.path, ']')
+
+		c.diffMissing(a.Index(i))
+
+		c.path = c.path[:l]
+	}
+
+	for j, u := range used {
+		if u {
+			continue
+		}
+
+		eq = false
+
+		l := len(c.path)
+		c.path = append(c.path, '[')
+		c.path = strconv.AppendInt(c.path, int64(j), 10)
+		c.path = append(c.path, ']')
+
+		c.diffExtra(b.Index(j))
+
+		c.path = c.path[:l]
+	}
+
+	return eq
 }
 
-func equalFunc(a, b reflect.Value, visited map[visit]struct{}) bool {
+func (c *comparer) equalFunc(a, b reflect.Value) bool {
 	if a.IsNil() && b.IsNil() {
 		return true
 	}
@@ -284,13 +518,19 @@ func equalFunc(a, b reflect.Value, visited map[visit]struct{}) bool {
 	panic("can't compare funcs")
 }
 
+// maxDepth limits how deep Fprint goes into nested values.
+const maxDepth = 10
+
 func Fprint(w io.Writer, x ...interface{}) (n int, err error) {
 	f := formatter{
 		Writer: w,
 	}
 
 	for i, x := range x {
-		n, err = f.print(n, reflect.ValueOf(x), 0, 10)
+		f.refs = nil
+		f.scan(reflect.ValueOf(x), 0, maxDepth)
+
+		n, err = f.print(n, reflect.ValueOf(x), 0, maxDepth)
 		if err != nil {
 			return n, fmt.Errorf("arg %d: %w", i, err)
 		}
@@ -310,8 +550,8 @@ func (f *formatter) print(n int, x reflect.Value, d, maxdepth int) (m int, err e
 
 	tp := x.Type()
 
-	if _, ok := stop[tp]; ok {
-		return f.writef(n, "%#v", x)
+	if m, ok, err := f.custom(n, x); ok {
+		return m, err
 	}
 
 	if d == maxdepth {
@@ -323,16 +563,23 @@ func (f *formatter) print(n int, x reflect.Value, d, maxdepth int) (m int, err e
 			return f.writef(n, "(%v)(nil)", x.Type())
 		}
 
-		n, err = f.writef(n, "&")
-		if err != nil {
-			return
+		var r *ref
+		var done bool
+
+		n, r, done, err = f.ref(n, x, "&")
+		if err != nil || done {
+			return n, err
+		}
+
+		if r != nil {
+			defer func() { r.active = false }()
 		}
 
 		x = x.Elem()
-	}
 
-	if _, ok := stop[tp]; ok {
-		return f.writef(n, "%#v", x)
+		if m, ok, err := f.custom(n, x); ok {
+			return m, err
+		}
 	}
 
 	named := x.Type().Name() != x.Kind().String()
@@ -349,10 +596,26 @@ func (f *formatter) print(n int, x reflect.Value, d, maxdepth int) (m int, err e
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 		reflect.Uintptr, reflect.UnsafePointer:
 
-		n, err = f.writef(n, "%v(0x%x)", x.Type(), x)
+		if f.full && x.Kind() == reflect.UnsafePointer && !x.IsNil() {
+			return f.writef(n, "%v(non-nil)", x.Type())
+		}
+
+		// not x itself, as fmt would call its String method
+		var v interface{}
+
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v = x.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			v = x.Uint()
+		default:
+			v = x.Pointer()
+		}
+
+		n, err = f.writef(n, "%v(0x%x)", x.Type(), v)
 	case reflect.String:
 		vf := "%q"
-		if x.Len() > 40 {
+		if x.Len() > 40 && !f.full {
 			vf = "%-.40q"
 		}
 
@@ -368,7 +631,7 @@ func (f *formatter) print(n int, x reflect.Value, d, maxdepth int) (m int, err e
 		}
 
 		if tp := x.Type(); tp.Elem().Kind() == reflect.Uint8 {
-			if x.Len() > 20 {
+			if x.Len() > 20 && !f.full {
 				format := `unhex("%x", "total_len=%d,hash=%x")`
 				if isPrintable(x.Slice(0, 20).Bytes()) {
 					format = `%q, "total_len=%d,hash=%x"`
@@ -385,6 +648,20 @@ func (f *formatter) print(n int, x reflect.Value, d, maxdepth int) (m int, err e
 			return f.writef(n, `%v(`+format+`)`, tp, x.Slice(0, x.Len()).Bytes())
 		}
 
+		if x.Kind() == reflect.Slice && x.Len() != 0 {
+			var r *ref
+			var done bool
+
+			n, r, done, err = f.ref(n, x, "")
+			if err != nil || done {
+				return n, err
+			}
+
+			if r != nil {
+				defer func() { r.active = false }()
+			}
+		}
+
 		n, err = f.writef(n, "%v", x.Type())
 		if err != nil {
 			return
@@ -418,6 +695,65 @@ func (f *formatter) print(n int, x reflect.Value, d, maxdepth int) (m int, err e
 		}
 
 		n, err = f.ident(n, d, ")")
+	case reflect.Float32, reflect.Float64:
+		n, err = f.writef(n, "%v(%s)", x.Type(), strconv.FormatFloat(x.Float(), 'g', -1, x.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		n, err = f.writef(n, "%v%s", x.Type(), strconv.FormatComplex(x.Complex(), 'g', -1, x.Type().Bits()))
+	case reflect.Map:
+		if x.IsNil() {
+			return f.writef(n, "%v(nil)", x.Type())
+		}
+
+		if x.Len() == 0 {
+			return f.writef(n, "%v{}", x.Type())
+		}
+
+		var r *ref
+		var done bool
+
+		n, r, done, err = f.ref(n, x, "")
+		if err != nil || done {
+			return n, err
+		}
+
+		if r != nil {
+			defer func() { r.active = false }()
+		}
+
+		n, err = f.writef(n, "%v{\n", x.Type())
+		if err != nil {
+			return
+		}
+
+		n, err = f.printMap(n, x, d+1, maxdepth)
+		if err != nil {
+			return
+		}
+
+		n, err = f.ident(n, d, "}")
+	case reflect.Chan:
+		if x.IsNil() {
+			return f.writef(n, "(%v)(nil)", x.Type())
+		}
+
+		n, err = f.writef(n, "(%v)(len=%d, cap=%d)", x.Type(), x.Len(), x.Cap())
+	case reflect.Func:
+		if x.IsNil() {
+			return f.writef(n, "(%v)(nil)", x.Type())
+		}
+
+		fn := runtime.FuncForPC(x.Pointer())
+		if fn == nil {
+			return f.writef(n, "(%v)(unknown)", x.Type())
+		}
+
+		if f.full {
+			return f.writef(n, "(%v)(%s)", x.Type(), fn.Name())
+		}
+
+		file, line := fn.FileLine(fn.Entry())
+
+		n, err = f.writef(n, "(%v)(%s at %s:%d)", x.Type(), fn.Name(), filepath.Base(file), line)
 	default:
 		n, err = f.writef(n, "%v", x.Type())
 		if err != nil {
@@ -487,6 +823,40 @@ func (f *formatter) printStructFields(n int, x reflect.Value, d, maxdepth int) (
 	return n, nil
 }
 
+func (f *formatter) printMap(n int, x reflect.Value, d, maxdepth int) (_ int, err error) {
+	keys := x.MapKeys()
+	f.sortKeys(keys)
+
+	for _, k := range keys {
+		n, err = f.ident(n, d, "")
+		if err != nil {
+			return
+		}
+
+		n, err = f.print(n, k, d, maxdepth)
+		if err != nil {
+			return
+		}
+
+		n, err = f.writef(n, ": ")
+		if err != nil {
+			return
+		}
+
+		n, err = f.print(n, x.MapIndex(k), d, maxdepth)
+		if err != nil {
+			return
+		}
+
+		n, err = f.writef(n, "\n")
+		if err != nil {
+			return
+		}
+	}
+
+	return n, nil
+}
+
 func (f *formatter) printSlice(n int, x reflect.Value, d, maxdepth int) (m int, err error) {
 	t := x.Type().Elem()
 	k := t.Kind()
@@ -526,7 +896,7 @@ func (f *formatter) printSlice(n int, x reflect.Value, d, maxdepth int) (m int,
 				}
 			}
 
-			if i == 10 {
+			if i == 10 && !f.full {
 				n, err = f.writef(n, "... %d elements", x.Len()-i)
 				if err != nil {
 					return
@@ -639,7 +1009,18 @@ func getTag(x reflect.StructField, t, k string) (string, bool) {
 	return "", false
 }
 
-func (w *prefixWriter) Write(p []byte) (n int, err error) {
+// NewPrefixWriter creates PrefixWriter.
+// If first is false the first line is not prefixed,
+// which is useful when it's continued after a label.
+func NewPrefixWriter(w io.Writer, prefix string, first bool) *PrefixWriter {
+	return &PrefixWriter{
+		Writer: w,
+		pref:   []byte(prefix),
+		add:    first,
+	}
+}
+
+func (w *PrefixWriter) Write(p []byte) (n int, err error) {
 	i := 0
 
 	for i < len(p) {
@@ -648,6 +1029,8 @@ func (w *prefixWriter) Write(p []byte) (n int, err error) {
 			if err != nil {
 				return
 			}
+
+			w.add = false
 		}
 
 		st := i
@@ -2,7 +2,17 @@ package deep
 
 import (
 	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 type (
@@ -109,4 +119,675 @@ func TestEqual(t *testing.T) {
 	if eq {
 		t.Errorf("excepted to not to be equal")
 	}
+
+	//
+
+	if !Equal([]int(nil), []int{}) || !Equal(map[int]int(nil), map[int]int{}) {
+		t.Errorf("excepted nil and empty to be equal")
+	}
+
+	if EqualWith([]int(nil), []int{}, StrictNil()) || EqualWith(map[int]int(nil), map[int]int{}, StrictNil()) {
+		t.Errorf("excepted nil and empty to not to be equal with StrictNil")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type M struct {
+		M map[string]int
+		P *A
+	}
+
+	x := B{
+		A: A{
+			A: 1,
+			B: "second",
+			D: []int{1, 2, 3},
+		},
+		D: []int{1, 2},
+		E: 5,
+	}
+
+	y := B{
+		A: A{
+			A: 2,
+			B: "second",
+			D: []int{1, 2, 4},
+		},
+		D: []int{1, 2, 3},
+		E: int64(5),
+	}
+
+	var buf bytes.Buffer
+
+	eq := Diff(&buf, x, y)
+	if eq {
+		t.Errorf("expected to be not equal")
+	}
+
+	exp := `.A.A: 1 != 2
+.A.D[2]: 3 != 4
+.D[2]: extra in actual: 3
+.E: int(5) != int64(5)
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	buf.Reset()
+
+	eq = Diff(&buf, M{
+		M: map[string]int{"a": 1, "key": 2, "z": 3},
+		P: &A{A: 1},
+	}, M{
+		M: map[string]int{"a": 1, "z": 4, "new": 5},
+	})
+	if eq {
+		t.Errorf("expected to be not equal")
+	}
+
+	exp = `.M["key"]: missing in actual: 2
+.M["new"]: extra in actual: 5
+.M["z"]: 3 != 4
+.P: &deep.A{A:1, B:"", C:0x0, D:[]int(nil)} != (*deep.A)(nil)
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	buf.Reset()
+
+	eq = Diff(&buf, x, x)
+	if !eq || buf.Len() != 0 {
+		t.Errorf("expected to be equal: %v\n%s", eq, buf.Bytes())
+	}
+}
+
+func TestDiffText(t *testing.T) {
+	var buf bytes.Buffer
+
+	eq := Diff(&buf, A{B: "hello world"}, A{B: "hello wOrld"})
+	if eq {
+		t.Errorf("expected to be not equal")
+	}
+
+	exp := `.B: "hello world" != "hello wOrld"
+            ^ at rune 7
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	buf.Reset()
+
+	eq = Diff(&buf, []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"), []byte("a\nb\nX\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"))
+	if eq {
+		t.Errorf("expected to be not equal")
+	}
+
+	exp = `text differs:
+--- expected
++++ actual
+@@ -1,6 +1,6 @@
+ a
+ b
+-c
++X
+ d
+ e
+ f
+@@ -9,3 +9,4 @@
+ i
+ j
+ k
++l
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	buf.Reset()
+
+	Diff(&buf, "a\nb\n", "a\nb")
+
+	exp = `text differs only in the final newline:
+\ No newline at end of actual
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	var a, b strings.Builder
+
+	for i := 0; i < 3000; i++ {
+		fmt.Fprintf(&a, "a%d\n", i)
+		fmt.Fprintf(&b, "b%d\n", i)
+	}
+
+	buf.Reset()
+
+	Diff(&buf, a.String(), b.String())
+
+	exp = `text differs:
+too many changes to show (3000 vs 3000 lines)
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+}
+
+func TestHexDiff(t *testing.T) {
+	a := make([]byte, 64)
+	for i := range a {
+		a[i] = byte(i * 7)
+	}
+
+	b := append([]byte{}, a[:60]...)
+	b[40] = 1
+
+	var buf bytes.Buffer
+
+	eq := HexDiff(&buf, a, b)
+	if eq {
+		t.Errorf("expected to be not equal")
+	}
+
+	exp := `first difference at offset 0x28 (len 64 vs 60)
+*
+00000010  70 77 7e 85 8c 93 9a a1  a8 af b6 bd c4 cb d2 d9  |pw~.............|    70 77 7e 85 8c 93 9a a1  a8 af b6 bd c4 cb d2 d9  |pw~.............|
+00000020  e0 e7 ee f5 fc 03 0a 11  18 1f 26 2d 34 3b 42 49  |..........&-4;BI| !  e0 e7 ee f5 fc 03 0a 11  01 1f 26 2d 34 3b 42 49  |..........&-4;BI|
+00000030  50 57 5e 65 6c 73 7a 81  88 8f 96 9d a4 ab b2 b9  |PW^elsz.........| !  50 57 5e 65 6c 73 7a 81  88 8f 96 9d              |PW^elsz.....    |
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	buf.Reset()
+
+	eq = Diff(&buf, [4]byte{1, 2, 3, 4}, [4]byte{1, 2, 0, 4})
+	if eq {
+		t.Errorf("expected to be not equal")
+	}
+
+	if !bytes.HasPrefix(buf.Bytes(), []byte("binary differs:\nfirst difference at offset 0x2 ")) {
+		t.Errorf("diff:\n%s", buf.Bytes())
+	}
+}
+
+func TestFloatTolerance(t *testing.T) {
+	type F struct {
+		F float64
+		C complex128
+	}
+
+	a := F{F: 0.1 + 0.2, C: complex(1, 1)}
+	b := F{F: 0.3, C: complex(1, 1.0000001)}
+
+	if Equal(a, b) {
+		t.Errorf("expected to be not equal")
+	}
+
+	if !EqualWith(a, b, FloatTolerance(1e-6, 0)) {
+		t.Errorf("expected to be equal with abs tolerance")
+	}
+
+	if !EqualWith(a, b, FloatTolerance(0, 1e-6)) {
+		t.Errorf("expected to be equal with rel tolerance")
+	}
+
+	nan := math.NaN()
+
+	if Equal(nan, nan) || !EqualWith(nan, nan, NaNEqual()) {
+		t.Errorf("bad NaN handling")
+	}
+
+	inf := math.Inf(1)
+	tol := FloatTolerance(1, 0.5)
+
+	if !EqualWith(inf, inf, tol) || EqualWith(inf, 1.0, tol) || EqualWith(inf, -inf, tol) || EqualWith(1.0, -inf, tol) {
+		t.Errorf("bad Inf handling")
+	}
+
+	if EqualWith(complex(inf, 0), complex(1, 0), tol) || EqualWith(complex(0, inf), complex(0, -inf), tol) {
+		t.Errorf("bad complex Inf handling")
+	}
+
+	var buf bytes.Buffer
+
+	DiffWith(&buf, []float64{1, 2}, []float64{1, 2.5}, FloatTolerance(0.1, 0))
+
+	if exp := "[1]: 2 != 2.5 (delta 0.5)\n"; buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	type (
+		Item struct {
+			ID   int
+			Name string
+			priv int
+		}
+
+		List struct {
+			Items []Item
+			M     map[string]int
+			S     []int
+		}
+	)
+
+	a := List{
+		Items: []Item{{ID: 1, Name: "a", priv: 1}, {ID: 2, Name: "b"}},
+		M:     map[string]int{},
+	}
+
+	b := List{
+		Items: []Item{{ID: 3, Name: "b"}, {ID: 4, Name: "a", priv: 2}},
+		S:     []int{},
+	}
+
+	var buf bytes.Buffer
+
+	if DiffWith(&buf, a, b, IgnoreFields(".Items[*].ID"), IgnoreUnexported(), IgnoreOrder(), StrictNil()) {
+		t.Errorf("expected to be not equal")
+	}
+
+	exp := `.M: map[string]int{} != map[string]int(nil)
+.S: []int(nil) != []int{}
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	if !EqualWith(a, b, IgnoreFields("ID"), IgnoreUnexported(), IgnoreOrder()) {
+		t.Errorf("expected to be equal")
+	}
+
+	if EqualWith(a, b, IgnoreFields("ID"), IgnoreOrder()) {
+		t.Errorf("expected to be not equal: unexported fields")
+	}
+
+	type (
+		Point struct{ X, Y int }
+
+		Nested struct {
+			M map[string]Point
+			L []map[string]Point
+		}
+	)
+
+	na := Nested{M: map[string]Point{"k": {X: 1, Y: 1}}, L: []map[string]Point{{"k": {X: 1, Y: 1}}}}
+	nb := Nested{M: map[string]Point{"k": {X: 1, Y: 2}}, L: []map[string]Point{{"k": {X: 1, Y: 2}}}}
+
+	for _, opts := range [][]Option{
+		{IgnoreFields(`.M["k"].Y`), IgnoreFields(`.L[0]["k"].Y`)},
+		{IgnoreFields(`.M[*].Y`), IgnoreFields(`.L[*][*].Y`), IgnoreOrder()},
+		{IgnoreFields("Y")},
+	} {
+		if !EqualWith(na, nb, opts...) {
+			t.Errorf("expected to be equal with ignored map value fields")
+		}
+
+		buf.Reset()
+
+		if !DiffWith(&buf, na, nb, opts...) {
+			t.Errorf("expected to be equal with ignored map value fields:\n%s", buf.Bytes())
+		}
+	}
+
+	if EqualWith(na, nb, IgnoreFields(`.M["x"].Y`), IgnoreOrder()) {
+		t.Errorf("expected to be not equal")
+	}
+
+	byName := Comparer(func(a, b Item) bool { return a.Name == b.Name })
+
+	if !EqualWith(a.Items, []Item{{Name: "a"}, {Name: "b"}}, byName) {
+		t.Errorf("expected to be equal with custom comparer")
+	}
+}
+
+func TestEqualMethods(t *testing.T) {
+	type T struct {
+		T time.Time
+		I *big.Int
+	}
+
+	now := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	a := T{T: now, I: big.NewInt(10)}
+	b := T{T: now.In(time.FixedZone("X", 3600)), I: big.NewInt(10)}
+
+	if !Equal(a, b) {
+		t.Errorf("expected to be equal")
+	}
+
+	if EqualWith(a, b, IgnoreEqualMethods()) {
+		t.Errorf("expected to be not equal without methods")
+	}
+
+	b.T = b.T.Add(time.Second)
+	b.I = big.NewInt(11)
+
+	var buf bytes.Buffer
+
+	Diff(&buf, a, b)
+
+	exp := `.T: 2020-01-02T03:04:05Z != 2020-01-02T04:04:06+01:00 (delta 1s)
+.I: 10 != 11
+`
+
+	if buf.String() != exp {
+		t.Errorf("diff:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+}
+
+type (
+	testID [4]byte
+
+	testName struct {
+		first, last string
+	}
+)
+
+func TestPrinters(t *testing.T) {
+	type T struct {
+		ID   testID
+		Name testName
+		IP   net.IP
+	}
+
+	RegisterPrinter(reflect.TypeOf(testName{}), func(w io.Writer, v reflect.Value) error {
+		n := v.Interface().(testName)
+
+		_, err := fmt.Fprintf(w, "name(%s %s)", n.first, n.last)
+
+		return err
+	})
+	defer RegisterPrinter(reflect.TypeOf(testName{}), nil)
+
+	x := T{
+		ID:   testID{1, 2, 3, 4},
+		Name: testName{"John", "Doe"},
+		IP:   net.IPv4(10, 0, 0, 1),
+	}
+
+	var buf bytes.Buffer
+
+	_, err := FprintWith(&buf, x, UseStringer())
+	if err != nil {
+		t.Errorf("fprint: %v", err)
+	}
+
+	exp := `deep.T{
+    ID:             deep.testID("01020304")
+    Name:           name(John Doe)
+    IP:             net.IP("10.0.0.1")
+}`
+
+	if buf.String() != exp {
+		t.Errorf("print:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+}
+
+func (id testID) String() string { return fmt.Sprintf("%x", id[:]) }
+
+func TestFprintKinds(t *testing.T) {
+	type T struct {
+		M  map[interface{}]int
+		F  float64
+		C  complex64
+		Ch chan int
+		Fn func() error
+		N  map[string]int
+	}
+
+	ch := make(chan int, 3)
+	ch <- 1
+
+	x := T{
+		M:  map[interface{}]int{"b": 1, 2: 2, "a": 3, 1: 4},
+		F:  0.1,
+		C:  complex(1, -2),
+		Ch: ch,
+		Fn: testFunc,
+	}
+
+	var buf bytes.Buffer
+
+	_, err := Fprint(&buf, x)
+	if err != nil {
+		t.Errorf("fprint: %v", err)
+	}
+
+	exp := `deep.T{
+    M:              map[interface {}]int{
+        (interface {})(int(0x1)): int(0x4)
+        (interface {})(int(0x2)): int(0x2)
+        (interface {})("a"): int(0x3)
+        (interface {})("b"): int(0x1)
+    }
+    F:              float64(0.1)
+    C:              complex64(1-2i)
+    Ch:             (chan int)(len=1, cap=3)
+    Fn:             (func() error)(github.com/nikandfor/assert/deep.testFunc at deep_test.go:%d)
+    N:              map[string]int(nil)
+}`
+
+	_, line := runtime.FuncForPC(reflect.ValueOf(testFunc).Pointer()).FileLine(reflect.ValueOf(testFunc).Pointer())
+
+	exp = fmt.Sprintf(exp, line)
+
+	if buf.String() != exp {
+		t.Errorf("print:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+}
+
+func testFunc() error { return nil }
+
+func TestFprintRefs(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+		Peer *A
+	}
+
+	shared := &A{A: 1}
+
+	a := &Node{Name: "a", Peer: shared}
+	b := &Node{Name: "b", Next: a, Peer: shared}
+	a.Next = b
+
+	var buf bytes.Buffer
+
+	_, err := Fprint(&buf, a)
+	if err != nil {
+		t.Errorf("fprint: %v", err)
+	}
+
+	exp := `&#1 deep.Node{
+    Name:           "a"
+    Next:           &deep.Node{
+        Name:           "b"
+        Next:           <cycle #1>
+        Peer:           &#2 deep.A{
+            A:              int(0x1)
+            B:              ""
+            C:              uint64(0x0)
+            D:              []int(nil)
+        }
+    }
+    Peer:           <ref #2>
+}`
+
+	if buf.String() != exp {
+		t.Errorf("print:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	s := []interface{}{1, nil}
+	s[1] = s
+
+	buf.Reset()
+
+	_, err = Fprint(&buf, s)
+	if err != nil {
+		t.Errorf("fprint: %v", err)
+	}
+
+	if exp := `#1 []interface {}{(interface {})(int(0x1)), (interface {})(<cycle #1>)}`; buf.String() != exp {
+		t.Errorf("print:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	buf.Reset()
+
+	_, err = FprintWith(&buf, s, GoSyntax())
+	if err != nil {
+		t.Errorf("fprint: %v", err)
+	}
+
+	if exp := "[]interface {}{\n\t1,\n\tnil /* cycle */,\n}"; buf.String() != exp {
+		t.Errorf("print:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+}
+
+func TestGoSyntax(t *testing.T) {
+	type Node struct {
+		Name  string
+		Score float64
+		Next  *Node
+		Tags  map[string]int
+		Data  []byte
+		Any   interface{}
+		Ptr   *int
+	}
+
+	x := 5
+
+	n := &Node{
+		Name:  "a",
+		Score: 1,
+		Tags:  map[string]int{"b": 2, "a": 1},
+		Data:  []byte("text"),
+		Any:   uint8(3),
+		Ptr:   &x,
+	}
+	n.Next = &Node{Name: "b", Data: []byte{0, 1}, Next: n}
+
+	var buf bytes.Buffer
+
+	_, err := FprintWith(&buf, n, GoSyntax())
+	if err != nil {
+		t.Errorf("fprint: %v", err)
+	}
+
+	exp := `&deep.Node{
+	Name: "a",
+	Score: 1,
+	Next: &deep.Node{
+		Name: "b",
+		Next: nil /* cycle */,
+		Data: []byte{0x00, 0x01},
+	},
+	Tags: map[string]int{
+		"a": 1,
+		"b": 2,
+	},
+	Data: []byte("text"),
+	Any: uint8(3),
+	Ptr: &[]int{5}[0],
+}`
+
+	if buf.String() != exp {
+		t.Errorf("print:\n%s\nexpected:\n%s", buf.Bytes(), exp)
+	}
+
+	for _, tc := range []struct {
+		x   interface{}
+		exp string
+	}{
+		{x: 1, exp: `1`},
+		{x: 1.5, exp: `1.5`},
+		{x: 2.0, exp: `float64(2)`},
+		{x: float32(0.25), exp: `float32(0.25)`},
+		{x: complex(1, -2), exp: `complex(1, -2)`},
+		{x: "str", exp: `"str"`},
+		{x: []int(nil), exp: `[]int(nil)`},
+		{x: []int{1, 2}, exp: `[]int{1, 2}`},
+		{x: [2]byte{'a', 'b'}, exp: `[2]byte{0x61, 0x62}`},
+		{x: []interface{}{1, "a", nil}, exp: "[]interface {}{\n\t1,\n\t\"a\",\n\tnil,\n}"},
+		{x: map[string]int{}, exp: `map[string]int{}`},
+		{x: A{}, exp: `deep.A{}`},
+		{x: (*A)(nil), exp: `(*deep.A)(nil)`},
+		{x: time.Duration(3), exp: `time.Duration(3)`},
+	} {
+		buf.Reset()
+
+		_, err := FprintWith(&buf, tc.x, GoSyntax())
+		if err != nil {
+			t.Errorf("fprint: %v", err)
+		}
+
+		if buf.String() != tc.exp {
+			t.Errorf("print %#v: %s, expected %s", tc.x, buf.Bytes(), tc.exp)
+		}
+	}
+}
+
+func TestFprintFull(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 5)
+
+	a, b := &A{A: 2}, &A{A: 1}
+
+	var buf bytes.Buffer
+
+	for _, tc := range []struct {
+		x   interface{}
+		exp string
+		gos bool
+	}{
+		{x: long, exp: strconv.Quote(long)},
+		{x: []byte(long), exp: `[]uint8(` + strconv.Quote(long) + `)`},
+		{x: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, exp: `[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}`},
+		{x: testFunc, exp: `(func() error)(github.com/nikandfor/assert/deep.testFunc)`},
+		{x: map[int]int{10: 0, 9: 0}, exp: "map[int]int{\n    int(0x9): int(0x0)\n    int(0xa): int(0x0)\n}"},
+		{x: map[*A]int{a: 2, b: 1}, exp: "map[*deep.A]int{\n\t&deep.A{\n\t\tA: 1,\n\t}: 1,\n\t&deep.A{\n\t\tA: 2,\n\t}: 2,\n}", gos: true},
+	} {
+		buf.Reset()
+
+		opts := []Option{Full()}
+		if tc.gos {
+			opts = append(opts, GoSyntax())
+		}
+
+		_, err := FprintWith(&buf, tc.x, opts...)
+		if err != nil {
+			t.Errorf("fprint: %v", err)
+		}
+
+		if buf.String() != tc.exp {
+			t.Errorf("print %#v:\n%s\nexpected:\n%s", tc.x, buf.Bytes(), tc.exp)
+		}
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewPrefixWriter(&buf, "> ", false)
+
+	fmt.Fprintf(w, "first")
+	fmt.Fprintf(w, " line\nsecond ")
+	fmt.Fprintf(w, "line\n\nfourth")
+
+	exp := "first line\n> second line\n> \n> fourth"
+
+	if buf.String() != exp {
+		t.Errorf("output: %q, expected %q", buf.Bytes(), exp)
+	}
 }
@@ -0,0 +1,64 @@
+package deep
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+func (c *comparer) diff(a, b reflect.Value) {
+	c.diffLine("%s != %s", short(a), short(b))
+}
+
+func (c *comparer) difft(a, b reflect.Value) {
+	c.diffLine("%s != %s", typed(a), typed(b))
+}
+
+func (c *comparer) diffPointer(a, b reflect.Value) {
+	c.diffLine("pointer %#x != %#x", a.Pointer(), b.Pointer())
+}
+
+func (c *comparer) diffMissing(a reflect.Value) {
+	c.diffLine("missing in actual: %s", short(a))
+}
+
+func (c *comparer) diffExtra(b reflect.Value) {
+	c.diffLine("extra in actual: %s", short(b))
+}
+
+func (c *comparer) diffTime(a, b time.Time) {
+	c.diffLine("%s != %s (delta %v)", a.Format(time.RFC3339Nano), b.Format(time.RFC3339Nano), b.Sub(a))
+}
+
+func (c *comparer) diffLine(format string, args ...interface{}) {
+	if c.w == nil {
+		return
+	}
+
+	if len(c.path) != 0 {
+		fmt.Fprintf(c.w, "%s: ", c.path)
+	}
+
+	fmt.Fprintf(c.w, format+"\n", args...)
+}
+
+func short(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	return fmt.Sprintf("%#v", v)
+}
+
+func typed(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
+		return fmt.Sprintf("%#v", v)
+	}
+
+	return fmt.Sprintf("%v(%#v)", v.Type(), v)
+}
@@ -0,0 +1,339 @@
+package deep
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GoSyntax makes Fprint produce compilable Go composite literals,
+// which can be pasted into tests as expected values.
+// Zero struct fields are omitted, maps are printed with sorted keys.
+func GoSyntax() Option {
+	return func(c *config) {
+		c.goSyntax = true
+	}
+}
+
+// printGo prints x as a Go expression.
+// implied means the type is known from the context,
+// like for struct fields and slice elements,
+// so untyped constants can be used.
+func (f *formatter) printGo(n int, x reflect.Value, d int, implied bool) (_ int, err error) {
+	if !x.IsValid() {
+		return f.writef(n, "nil")
+	}
+
+	t := x.Type()
+
+	if m, ok, err := f.custom(n, x); ok {
+		return m, err
+	}
+
+	conv := func(lit string, def bool) (int, error) {
+		if implied || def && t.Name() == t.Kind().String() && t.PkgPath() == "" {
+			return f.writef(n, "%s", lit)
+		}
+
+		if k := t.Kind(); t.Name() == "" && (k == reflect.Ptr || k == reflect.Chan || k == reflect.Func) {
+			return f.writef(n, "(%v)(%s)", t, lit)
+		}
+
+		return f.writef(n, "%v(%s)", t, lit)
+	}
+
+	switch x.Kind() {
+	case reflect.Bool:
+		return conv(strconv.FormatBool(x.Bool()), t.Kind() == reflect.Bool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return conv(strconv.FormatInt(x.Int(), 10), t.Kind() == reflect.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return conv(strconv.FormatUint(x.Uint(), 10), false)
+	case reflect.Float32, reflect.Float64:
+		v := x.Float()
+
+		switch {
+		case math.IsNaN(v):
+			return conv("math.NaN()", t.Kind() == reflect.Float64)
+		case math.IsInf(v, 0):
+			return conv("math.Inf("+strconv.Itoa(int(math.Copysign(1, v)))+")", t.Kind() == reflect.Float64)
+		}
+
+		lit := strconv.FormatFloat(v, 'g', -1, t.Bits())
+
+		return conv(lit, t.Kind() == reflect.Float64 && strings.ContainsAny(lit, ".e"))
+	case reflect.Complex64, reflect.Complex128:
+		c := x.Complex()
+		lit := "complex(" + strconv.FormatFloat(real(c), 'g', -1, t.Bits()/2) + ", " + strconv.FormatFloat(imag(c), 'g', -1, t.Bits()/2) + ")"
+
+		return conv(lit, t.Kind() == reflect.Complex128)
+	case reflect.String:
+		return conv(strconv.Quote(x.String()), t.Kind() == reflect.String)
+	case reflect.Interface:
+		if x.IsNil() {
+			return f.writef(n, "nil")
+		}
+
+		return f.printGo(n, x.Elem(), d, false)
+	case reflect.Ptr:
+		if x.IsNil() {
+			return conv("nil", false)
+		}
+
+		var r *ref
+		var done bool
+
+		n, r, done, err = f.goRef(n, x)
+		if err != nil || done {
+			return n, err
+		}
+
+		if r != nil {
+			defer func() { r.active = false }()
+		}
+
+		switch x.Elem().Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+			n, err = f.writef(n, "&")
+			if err != nil {
+				return
+			}
+
+			return f.printGo(n, x.Elem(), d, false)
+		}
+
+		n, err = f.writef(n, "&[]%v{", t.Elem())
+		if err != nil {
+			return
+		}
+
+		n, err = f.printGo(n, x.Elem(), d, true)
+		if err != nil {
+			return
+		}
+
+		return f.writef(n, "}[0]")
+	case reflect.Struct:
+		return f.printGoStruct(n, x, d)
+	case reflect.Slice, reflect.Array:
+		if x.Kind() == reflect.Slice && x.IsNil() {
+			return conv("nil", false)
+		}
+
+		if x.Kind() == reflect.Slice {
+			var r *ref
+			var done bool
+
+			n, r, done, err = f.goRef(n, x)
+			if err != nil || done {
+				return n, err
+			}
+
+			if r != nil {
+				defer func() { r.active = false }()
+			}
+		}
+
+		return f.printGoSlice(n, x, d)
+	case reflect.Map:
+		if x.IsNil() {
+			return conv("nil", false)
+		}
+
+		var r *ref
+		var done bool
+
+		n, r, done, err = f.goRef(n, x)
+		if err != nil || done {
+			return n, err
+		}
+
+		if r != nil {
+			defer func() { r.active = false }()
+		}
+
+		return f.printGoMap(n, x, d)
+	}
+
+	return f.writef(n, "nil /* %v */", t)
+}
+
+func (f *formatter) printGoStruct(n int, x reflect.Value, d int) (_ int, err error) {
+	t := x.Type()
+
+	n, err = f.writef(n, "%v{", t)
+	if err != nil {
+		return
+	}
+
+	fields := 0
+
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if ft.Tag.Get("deep") == "-" || x.Field(i).IsZero() {
+			continue
+		}
+
+		if v, ok := getTag(ft, "deep", "print"); ok && v == "omit" {
+			continue
+		}
+
+		fields++
+
+		n, err = f.writef(n, "\n%s%s: ", tabs(d+1), ft.Name)
+		if err != nil {
+			return
+		}
+
+		n, err = f.printGo(n, x.Field(i), d+1, true)
+		if err != nil {
+			return
+		}
+
+		n, err = f.writef(n, ",")
+		if err != nil {
+			return
+		}
+	}
+
+	if fields != 0 {
+		n, err = f.writef(n, "\n%s", tabs(d))
+		if err != nil {
+			return
+		}
+	}
+
+	return f.writef(n, "}")
+}
+
+func (f *formatter) printGoSlice(n int, x reflect.Value, d int) (_ int, err error) {
+	t := x.Type()
+
+	tname := t.String()
+	if t.Name() == "" && t.Elem() == reflect.TypeOf(byte(0)) {
+		tname = strings.TrimSuffix(tname, "uint8") + "byte"
+	}
+
+	if t.Elem().Kind() == reflect.Uint8 && x.Kind() == reflect.Slice && isText(x.Bytes()) {
+		return f.writef(n, "%s(%q)", tname, x.Bytes())
+	}
+
+	n, err = f.writef(n, "%s{", tname)
+	if err != nil {
+		return
+	}
+
+	multiline := false
+
+	switch t.Elem().Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
+		multiline = x.Len() != 0
+	}
+
+	for i := 0; i < x.Len(); i++ {
+		switch {
+		case multiline:
+			n, err = f.writef(n, "\n%s", tabs(d+1))
+		case i != 0:
+			n, err = f.writef(n, ", ")
+		}
+		if err != nil {
+			return
+		}
+
+		if t.Elem().Kind() == reflect.Uint8 {
+			n, err = f.writef(n, "0x%02x", x.Index(i).Uint())
+		} else {
+			n, err = f.printGo(n, x.Index(i), d+1, true)
+		}
+		if err != nil {
+			return
+		}
+
+		if multiline {
+			n, err = f.writef(n, ",")
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if multiline {
+		n, err = f.writef(n, "\n%s", tabs(d))
+		if err != nil {
+			return
+		}
+	}
+
+	return f.writef(n, "}")
+}
+
+func (f *formatter) printGoMap(n int, x reflect.Value, d int) (_ int, err error) {
+	n, err = f.writef(n, "%v{", x.Type())
+	if err != nil {
+		return
+	}
+
+	keys := x.MapKeys()
+	f.sortKeys(keys)
+
+	for _, k := range keys {
+		n, err = f.writef(n, "\n%s", tabs(d+1))
+		if err != nil {
+			return
+		}
+
+		n, err = f.printGo(n, k, d+1, true)
+		if err != nil {
+			return
+		}
+
+		n, err = f.writef(n, ": ")
+		if err != nil {
+			return
+		}
+
+		n, err = f.printGo(n, x.MapIndex(k), d+1, true)
+		if err != nil {
+			return
+		}
+
+		n, err = f.writef(n, ",")
+		if err != nil {
+			return
+		}
+	}
+
+	if len(keys) != 0 {
+		n, err = f.writef(n, "\n%s", tabs(d))
+		if err != nil {
+			return
+		}
+	}
+
+	return f.writef(n, "}")
+}
+
+// goRef is like ref, but only cycles are interrupted
+// as Go literals can't express shared references.
+func (f *formatter) goRef(n int, x reflect.Value) (_ int, r *ref, done bool, err error) {
+	r = f.refs[key(x)]
+
+	switch {
+	case r == nil || r.count < 2:
+		return n, nil, false, nil
+	case r.active:
+		n, err = f.writef(n, "nil /* cycle */")
+
+		return n, nil, true, err
+	}
+
+	r.active = true
+
+	return n, r, false, nil
+}
+
+func tabs(d int) string {
+	return strings.Repeat("\t", d)
+}
@@ -0,0 +1,117 @@
+package deep
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const hexRow = 16
+
+// HexDiff writes side-by-side hexdump -C style rendering of a and b.
+// Rows which are equal and not next to a different row are collapsed into "*".
+func HexDiff(w io.Writer, a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	fmt.Fprintf(w, "first difference at offset 0x%x (len %d vs %d)\n", i, len(a), len(b))
+
+	rows := (len(a) + hexRow - 1) / hexRow
+	if r := (len(b) + hexRow - 1) / hexRow; r > rows {
+		rows = r
+	}
+
+	differs := func(r int) bool {
+		if r < 0 || r >= rows {
+			return false
+		}
+
+		return !bytes.Equal(hexRowBytes(a, r), hexRowBytes(b, r))
+	}
+
+	var buf []byte
+	collapsed := false
+
+	for r := 0; r < rows; r++ {
+		d := differs(r)
+
+		if !d && !differs(r-1) && !differs(r+1) {
+			if !collapsed {
+				fmt.Fprintf(w, "*\n")
+			}
+
+			collapsed = true
+
+			continue
+		}
+
+		collapsed = false
+
+		mark := byte(' ')
+		if d {
+			mark = '!'
+		}
+
+		buf = append(buf[:0], fmt.Sprintf("%08x  ", r*hexRow)...)
+		buf = appendHexRow(buf, hexRowBytes(a, r))
+		buf = append(buf, ' ', mark, ' ', ' ')
+		buf = appendHexRow(buf, hexRowBytes(b, r))
+		buf = append(buf, '\n')
+
+		_, _ = w.Write(buf)
+	}
+
+	return false
+}
+
+func hexRowBytes(b []byte, r int) []byte {
+	st := r * hexRow
+	if st >= len(b) {
+		return nil
+	}
+
+	end := st + hexRow
+	if end > len(b) {
+		end = len(b)
+	}
+
+	return b[st:end]
+}
+
+func appendHexRow(buf, row []byte) []byte {
+	const hex = "0123456789abcdef"
+
+	for i := 0; i < hexRow; i++ {
+		if i == hexRow/2 {
+			buf = append(buf, ' ')
+		}
+
+		if i >= len(row) {
+			buf = append(buf, "   "...)
+			continue
+		}
+
+		buf = append(buf, hex[row[i]>>4], hex[row[i]&0xf], ' ')
+	}
+
+	buf = append(buf, ' ', '|')
+
+	for i := 0; i < hexRow; i++ {
+		switch {
+		case i >= len(row):
+			buf = append(buf, ' ')
+		case row[i] >= 0x20 && row[i] < 0x7f:
+			buf = append(buf, row[i])
+		default:
+			buf = append(buf, '.')
+		}
+	}
+
+	return append(buf, '|')
+}
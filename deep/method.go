@@ -0,0 +1,105 @@
+package deep
+
+import (
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// IgnoreEqualMethods disables using Equal(T) bool and Cmp(T) int methods
+// and compares such values structurally.
+func IgnoreEqualMethods() Option {
+	return func(c *config) {
+		c.noMethods = true
+	}
+}
+
+// equalMethod compares a and b with their Equal(T) bool or Cmp(T) int method if they have one.
+func (c *comparer) equalMethod(a, b reflect.Value) (eq, ok bool) {
+	t := a.Type()
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return false, false
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if a.IsNil() || b.IsNil() {
+			return false, false
+		}
+	}
+
+	for _, name := range []string{"Equal", "Cmp"} {
+		ptr, found := findMethod(t, name)
+		if !found {
+			continue
+		}
+
+		ea, ok := exported(a)
+		if !ok {
+			return false, false
+		}
+
+		eb, ok := exported(b)
+		if !ok {
+			return false, false
+		}
+
+		recv := ea
+
+		if ptr {
+			if !ea.CanAddr() {
+				recv = reflect.New(t)
+				recv.Elem().Set(ea)
+			} else {
+				recv = ea.Addr()
+			}
+		}
+
+		res := recv.MethodByName(name).Call([]reflect.Value{eb})[0]
+
+		if name == "Equal" {
+			eq = res.Bool()
+		} else {
+			eq = res.Int() == 0
+		}
+
+		switch {
+		case eq:
+		case t == timeType:
+			c.diffTime(ea.Interface().(time.Time), eb.Interface().(time.Time))
+		default:
+			c.diffLine("%v != %v", ea.Interface(), eb.Interface())
+		}
+
+		return eq, true
+	}
+
+	return false, false
+}
+
+// findMethod finds method name(T) bool for Equal or name(T) int for Cmp on T or *T.
+func findMethod(t reflect.Type, name string) (ptr, ok bool) {
+	check := func(m reflect.Method) bool {
+		mt := m.Type
+
+		if mt.NumIn() != 2 || mt.In(1) != t || mt.NumOut() != 1 {
+			return false
+		}
+
+		if name == "Equal" {
+			return mt.Out(0).Kind() == reflect.Bool
+		}
+
+		return mt.Out(0).Kind() == reflect.Int
+	}
+
+	if m, ok := t.MethodByName(name); ok && check(m) {
+		return false, true
+	}
+
+	if m, ok := reflect.PointerTo(t).MethodByName(name); ok && check(m) {
+		return true, true
+	}
+
+	return false, false
+}
@@ -0,0 +1,230 @@
+package deep
+
+import (
+	"io"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"regexp"
+	"strings"
+	"unsafe"
+)
+
+type (
+	Option func(c *config)
+
+	config struct {
+		floatAbs float64
+		floatRel float64
+		nanEqual bool
+
+		ignoreUnexported bool
+		ignoreOrder      bool
+		strictNil        bool
+		noMethods        bool
+
+		ignore    []*regexp.Regexp
+		comparers map[reflect.Type]reflect.Value
+
+		stringer      bool
+		goStringer    bool
+		textMarshaler bool
+
+		goSyntax bool
+		full     bool
+	}
+)
+
+func EqualWith(a, b interface{}, opts ...Option) bool {
+	c := comparer{}
+
+	for _, o := range opts {
+		o(&c.config)
+	}
+
+	return c.equal(addressable(a), addressable(b))
+}
+
+func DiffWith(w io.Writer, a, b interface{}, opts ...Option) bool {
+	c := comparer{
+		w: w,
+	}
+
+	for _, o := range opts {
+		o(&c.config)
+	}
+
+	return c.equal(addressable(a), addressable(b))
+}
+
+// FloatTolerance makes float and complex leaves equal
+// if they differ by no more than abs or by no more than rel
+// relative to the bigger of the absolute values.
+func FloatTolerance(abs, rel float64) Option {
+	return func(c *config) {
+		c.floatAbs = abs
+		c.floatRel = rel
+	}
+}
+
+// NaNEqual makes NaN equal to NaN.
+func NaNEqual() Option {
+	return func(c *config) {
+		c.nanEqual = true
+	}
+}
+
+// IgnoreUnexported skips unexported struct fields.
+func IgnoreUnexported() Option {
+	return func(c *config) {
+		c.ignoreUnexported = true
+	}
+}
+
+// IgnoreFields skips struct fields by path.
+// Path is in the Diff format like ".A.B[2].C" or ".M[\"key\"].D".
+// [*] matches any index or map key.
+// A path without leading dot or bracket matches the field with that name at any depth.
+func IgnoreFields(paths ...string) Option {
+	return func(c *config) {
+		for _, p := range paths {
+			c.ignore = append(c.ignore, pathRegexp(p))
+		}
+	}
+}
+
+// StrictNil makes nil slices and maps not equal to empty ones.
+// By default they are equal.
+func StrictNil() Option {
+	return func(c *config) {
+		c.strictNil = true
+	}
+}
+
+// IgnoreOrder compares slices and arrays as multisets.
+// Byte slices are still compared in order.
+func IgnoreOrder() Option {
+	return func(c *config) {
+		c.ignoreOrder = true
+	}
+}
+
+// Comparer sets a custom equality function for values of type T.
+func Comparer[T any](f func(a, b T) bool) Option {
+	return func(c *config) {
+		if c.comparers == nil {
+			c.comparers = make(map[reflect.Type]reflect.Value)
+		}
+
+		c.comparers[reflect.TypeOf(&f).Elem().In(0)] = reflect.ValueOf(f)
+	}
+}
+
+func (c *comparer) custom(f, a, b reflect.Value) (eq, ok bool) {
+	a, ok = exported(a)
+	if !ok {
+		return false, false
+	}
+
+	b, ok = exported(b)
+	if !ok {
+		return false, false
+	}
+
+	eq = f.Call([]reflect.Value{a, b})[0].Bool()
+	if !eq {
+		c.diff(a, b)
+	}
+
+	return eq, true
+}
+
+func (c *config) ignored(path []byte) bool {
+	for _, re := range c.ignore {
+		if re.Match(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pathRegexp(p string) *regexp.Regexp {
+	q := regexp.QuoteMeta(p)
+	q = strings.ReplaceAll(q, `\[\*\]`, `\[[^\]]*\]`)
+
+	if !strings.HasPrefix(p, ".") && !strings.HasPrefix(p, "[") {
+		q = `.*\.` + q
+	}
+
+	return regexp.MustCompile(`^` + q + `$`)
+}
+
+func addressable(x interface{}) reflect.Value {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		return v
+	}
+
+	p := reflect.New(v.Type()).Elem()
+	p.Set(v)
+
+	return p
+}
+
+// exported returns v usable with Interface and Call,
+// even if it was obtained through unexported fields.
+func exported(v reflect.Value) (reflect.Value, bool) {
+	if v.CanInterface() {
+		return v, true
+	}
+
+	if !v.CanAddr() {
+		return v, false
+	}
+
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem(), true
+}
+
+func (c *config) floatEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return c.nanEqual && math.IsNaN(a) && math.IsNaN(b)
+	}
+
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	d := math.Abs(a - b)
+
+	return d <= c.floatAbs || d <= c.floatRel*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func (c *config) complexEqual(a, b complex128) bool {
+	if a == b {
+		return true
+	}
+
+	an := math.IsNaN(real(a)) || math.IsNaN(imag(a))
+	bn := math.IsNaN(real(b)) || math.IsNaN(imag(b))
+
+	if an || bn {
+		return c.nanEqual && an && bn
+	}
+
+	if cmplx.IsInf(a) || cmplx.IsInf(b) {
+		return false
+	}
+
+	d := cmplxAbs(a - b)
+
+	return d <= c.floatAbs || d <= c.floatRel*math.Max(cmplxAbs(a), cmplxAbs(b))
+}
+
+func cmplxAbs(x complex128) float64 {
+	return math.Hypot(real(x), imag(x))
+}
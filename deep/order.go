@@ -0,0 +1,151 @@
+package deep
+
+import (
+	"bytes"
+	"cmp"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func sortKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return compare(keys[i], keys[j]) < 0
+	})
+}
+
+// sortKeys sorts map keys for printing.
+// In Full mode addresses are not used, keys equal otherwise
+// are ordered by their printed form.
+func (f *formatter) sortKeys(keys []reflect.Value) {
+	if !f.full {
+		sortKeys(keys)
+		return
+	}
+
+	str := make(map[int]string, len(keys))
+
+	printed := func(i int) string {
+		if s, ok := str[i]; ok {
+			return s
+		}
+
+		var b bytes.Buffer
+
+		p := formatter{Writer: &b, config: f.config}
+
+		p.scan(keys[i], 0, -1)
+
+		if p.goSyntax {
+			_, _ = p.printGo(0, keys[i], 0, true)
+		} else {
+			_, _ = p.print(0, keys[i], 0, -1)
+		}
+
+		str[i] = b.String()
+
+		return str[i]
+	}
+
+	idx := make([]int, len(keys))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.Slice(idx, func(i, j int) bool {
+		if c := compareValues(keys[idx[i]], keys[idx[j]], false); c != 0 {
+			return c < 0
+		}
+
+		return printed(idx[i]) < printed(idx[j])
+	})
+
+	sorted := make([]reflect.Value, len(keys))
+
+	for i, j := range idx {
+		sorted[i] = keys[j]
+	}
+
+	copy(keys, sorted)
+}
+
+// compare is a total ordering over values.
+// Values of different types are ordered by kind and then by type name.
+// Pointer-like values are ordered by address.
+// NaN is less than any other float.
+func compare(a, b reflect.Value) int {
+	return compareValues(a, b, true)
+}
+
+// compareValues is compare which considers all pointer-like values equal if addr is false.
+func compareValues(a, b reflect.Value, addr bool) int {
+	if !a.IsValid() || !b.IsValid() {
+		return cmpBool(a.IsValid(), b.IsValid())
+	}
+
+	if a.Type() != b.Type() {
+		if a.Kind() != b.Kind() {
+			return cmp.Compare(a.Kind(), b.Kind())
+		}
+
+		return strings.Compare(a.Type().String(), b.Type().String())
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return cmpBool(a.Bool(), b.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := cmp.Compare(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(imag(a.Complex()), imag(b.Complex()))
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Map:
+		if !addr {
+			return 0
+		}
+
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return cmpBool(!a.IsNil(), !b.IsNil())
+		}
+
+		return compareValues(a.Elem(), b.Elem(), addr)
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i), addr); c != 0 {
+				return c
+			}
+		}
+
+		return cmp.Compare(a.Len(), b.Len())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i), addr); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+
+	return 1
+}
@@ -0,0 +1,174 @@
+package deep
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	countWriter struct {
+		io.Writer
+		n int
+	}
+)
+
+var (
+	printersMu sync.RWMutex
+	printers   = map[reflect.Type]func(w io.Writer, v reflect.Value) error{
+		reflect.TypeOf(time.Time{}):      printGoSyntax,
+		reflect.TypeOf(&time.Location{}): printGoSyntax,
+		reflect.TypeOf(&big.Int{}):       printGoSyntax,
+		reflect.TypeOf(&os.File{}):       printGoSyntax,
+	}
+)
+
+// RegisterPrinter sets Fprint printer for values of type t.
+// Registered printers take precedence over UseStringer and similar options.
+// nil f removes the printer.
+func RegisterPrinter(t reflect.Type, f func(w io.Writer, v reflect.Value) error) {
+	printersMu.Lock()
+	defer printersMu.Unlock()
+
+	if f == nil {
+		delete(printers, t)
+		return
+	}
+
+	printers[t] = f
+}
+
+// UseStringer makes Fprint print fmt.Stringer values as type("String()").
+func UseStringer() Option {
+	return func(c *config) {
+		c.stringer = true
+	}
+}
+
+// UseGoStringer makes Fprint print fmt.GoStringer values with their GoString method.
+func UseGoStringer() Option {
+	return func(c *config) {
+		c.goStringer = true
+	}
+}
+
+// UseTextMarshaler makes Fprint print encoding.TextMarshaler values as type("MarshalText()").
+func UseTextMarshaler() Option {
+	return func(c *config) {
+		c.textMarshaler = true
+	}
+}
+
+// Full makes Fprint print the whole value the same way each time,
+// which is what golden files and snapshots need.
+// Strings, byte and int slices are not shortened, there is no depth limit,
+// addresses and source lines are not printed,
+// and map keys are sorted by their printed form.
+func Full() Option {
+	return func(c *config) {
+		c.full = true
+	}
+}
+
+func FprintWith(w io.Writer, x interface{}, opts ...Option) (n int, err error) {
+	f := formatter{
+		Writer: w,
+	}
+
+	for _, o := range opts {
+		o(&f.config)
+	}
+
+	if f.goSyntax {
+		// Go literals have no depth limit, all cycles go through
+		// pointers, maps or slices, so the scan stops anyway.
+		f.scan(reflect.ValueOf(x), 0, -1)
+
+		return f.printGo(0, reflect.ValueOf(x), 0, false)
+	}
+
+	maxdepth := maxDepth
+	if f.full {
+		maxdepth = -1
+	}
+
+	f.scan(reflect.ValueOf(x), 0, maxdepth)
+
+	return f.print(0, reflect.ValueOf(x), 0, maxdepth)
+}
+
+func (f *formatter) custom(n int, x reflect.Value) (_ int, ok bool, err error) {
+	printersMu.RLock()
+	p, ok := printers[x.Type()]
+	printersMu.RUnlock()
+
+	x, exp := exported(x)
+
+	if ok {
+		cw := countWriter{Writer: f}
+
+		err = p(&cw, x)
+
+		return n + cw.n, true, err
+	}
+
+	if !exp || !f.goStringer && !f.textMarshaler && !f.stringer {
+		return n, false, nil
+	}
+
+	if k := x.Kind(); (k == reflect.Ptr || k == reflect.Interface) && x.IsNil() {
+		return n, false, nil
+	}
+
+	vals := []interface{}{x.Interface()}
+	if x.CanAddr() {
+		vals = append(vals, x.Addr().Interface())
+	}
+
+	for _, v := range vals {
+		if v, ok := v.(fmt.GoStringer); ok && f.goStringer {
+			n, err = f.writef(n, "%s", v.GoString())
+			return n, true, err
+		}
+
+		if v, ok := v.(encoding.TextMarshaler); ok && f.textMarshaler {
+			t, err := v.MarshalText()
+			if err != nil {
+				return n, true, err
+			}
+
+			n, err = f.writef(n, "%v(%q)", x.Type(), t)
+			return n, true, err
+		}
+
+		if v, ok := v.(fmt.Stringer); ok && f.stringer {
+			n, err = f.writef(n, "%v(%q)", x.Type(), v.String())
+			return n, true, err
+		}
+	}
+
+	return n, false, nil
+}
+
+func printGoSyntax(w io.Writer, v reflect.Value) error {
+	if v.CanInterface() {
+		_, err := fmt.Fprintf(w, "%#v", v.Interface())
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%#v", v)
+
+	return err
+}
+
+func (w *countWriter) Write(p []byte) (n int, err error) {
+	n, err = w.Writer.Write(p)
+	w.n += n
+
+	return
+}
@@ -0,0 +1,133 @@
+package deep
+
+import (
+	"reflect"
+)
+
+type (
+	refKey struct {
+		p uintptr
+		t reflect.Type
+		n int // slice len, as sub-slices share the pointer
+	}
+
+	ref struct {
+		count  int
+		label  int
+		active bool
+	}
+)
+
+// scan counts pointers, maps and slices reachable from x
+// so shared and cyclic references could be labeled when printing.
+// It goes no deeper than maxdepth unless it's negative.
+func (f *formatter) scan(x reflect.Value, d, maxdepth int) {
+	if d == maxdepth {
+		return
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if x.IsNil() || x.Kind() == reflect.Slice && (x.Len() == 0 || x.Type().Elem().Kind() == reflect.Uint8) {
+			return
+		}
+
+		if f.refs == nil {
+			f.refs = make(map[refKey]*ref)
+		}
+
+		k := key(x)
+
+		r := f.refs[k]
+		if r == nil {
+			r = &ref{}
+			f.refs[k] = r
+		}
+
+		r.count++
+
+		if r.count > 1 {
+			return
+		}
+
+		switch x.Kind() {
+		case reflect.Ptr:
+			f.scan(x.Elem(), d, maxdepth)
+		case reflect.Slice:
+			for i := 0; i < x.Len(); i++ {
+				f.scan(x.Index(i), d+1, maxdepth)
+			}
+		case reflect.Map:
+			it := x.MapRange()
+			for it.Next() {
+				f.scan(it.Key(), d+1, maxdepth)
+				f.scan(it.Value(), d+1, maxdepth)
+			}
+		}
+	case reflect.Interface:
+		f.scan(x.Elem(), d+1, maxdepth)
+	case reflect.Array:
+		if x.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+
+		for i := 0; i < x.Len(); i++ {
+			f.scan(x.Index(i), d+1, maxdepth)
+		}
+	case reflect.Struct:
+		t := x.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			ft := t.Field(i)
+			if ft.Tag.Get("deep") == "-" {
+				continue
+			}
+
+			if v, ok := getTag(ft, "deep", "print"); ok && v == "omit" {
+				continue
+			}
+
+			f.scan(x.Field(i), d+1, maxdepth)
+		}
+	}
+}
+
+// ref writes prefix and a label for the first occurrence of a shared reference
+// or a back reference for the following ones, in which case done is true.
+// Returned r must be deactivated when x is printed.
+func (f *formatter) ref(n int, x reflect.Value, prefix string) (_ int, r *ref, done bool, err error) {
+	r = f.refs[key(x)]
+
+	switch {
+	case r == nil || r.count < 2:
+		n, err = f.writef(n, "%s", prefix)
+
+		return n, nil, false, err
+	case r.active:
+		n, err = f.writef(n, "<cycle #%d>", r.label)
+
+		return n, nil, true, err
+	case r.label != 0:
+		n, err = f.writef(n, "<ref #%d>", r.label)
+
+		return n, nil, true, err
+	}
+
+	f.labels++
+	r.label = f.labels
+	r.active = true
+
+	n, err = f.writef(n, "%s#%d ", prefix, r.label)
+
+	return n, r, false, err
+}
+
+func key(x reflect.Value) refKey {
+	k := refKey{p: x.Pointer(), t: x.Type()}
+
+	if x.Kind() == reflect.Slice {
+		k.n = x.Len()
+	}
+
+	return k
+}
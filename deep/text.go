@@ -0,0 +1,383 @@
+package deep
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	edit struct {
+		op   byte // ' ', '-', '+'
+		a, b int
+	}
+)
+
+const (
+	diffContext  = 3
+	diffWindow   = 30
+	diffMaxEdits = 1000
+)
+
+func (c *comparer) equalBytes(a, b reflect.Value) bool {
+	ab := bytesOf(a)
+	bb := bytesOf(b)
+
+	if bytes.Equal(ab, bb) {
+		return true
+	}
+
+	if c.w == nil {
+		return false
+	}
+
+	if isText(ab) && isText(bb) {
+		c.diffText(string(ab), string(bb))
+	} else {
+		c.diffLine("binary differs:")
+		HexDiff(c.w, ab, bb)
+	}
+
+	return false
+}
+
+func (c *comparer) diffText(a, b string) {
+	if c.w == nil {
+		return
+	}
+
+	if strings.IndexByte(a, '\n') == -1 && strings.IndexByte(b, '\n') == -1 {
+		i := CommonPrefix(a, b)
+
+		qa, ca := Snippet(a, i)
+		qb, _ := Snippet(b, i)
+
+		c.diffLine("%s != %s", qa, qb)
+
+		l := len(c.path)
+		if l != 0 {
+			l += 2
+		}
+
+		fmt.Fprintf(c.w, "%s^ at rune %d\n", strings.Repeat(" ", l+ca), utf8.RuneCountInString(a[:i]))
+
+		return
+	}
+
+	la, lb := splitLines(a), splitLines(b)
+
+	if equalLines(la, lb) {
+		c.diffLine("text differs only in the final newline:")
+	} else {
+		c.diffLine("text differs:")
+
+		writeUnified(c.w, la, lb, diffContext)
+	}
+
+	switch ea, eb := strings.HasSuffix(a, "\n"), strings.HasSuffix(b, "\n"); {
+	case ea && !eb:
+		fmt.Fprintf(c.w, "\\ No newline at end of actual\n")
+	case !ea && eb:
+		fmt.Fprintf(c.w, "\\ No newline at end of expected\n")
+	}
+}
+
+func writeUnified(w io.Writer, a, b []string, context int) {
+	es, ok := myers(a, b)
+	if !ok {
+		fmt.Fprintf(w, "too many changes to show (%d vs %d lines)\n", len(a), len(b))
+
+		return
+	}
+
+	fmt.Fprintf(w, "--- expected\n+++ actual\n")
+
+	for st := 0; st < len(es); {
+		for st < len(es) && es[st].op == ' ' {
+			st++
+		}
+
+		if st == len(es) {
+			break
+		}
+
+		start := st - context
+		if start < 0 {
+			start = 0
+		}
+
+		end := st
+		for end < len(es) {
+			if es[end].op != ' ' {
+				end++
+				continue
+			}
+
+			eq := end
+			for eq < len(es) && es[eq].op == ' ' {
+				eq++
+			}
+
+			if eq == len(es) || eq-end > 2*context {
+				end += context
+				if end > eq {
+					end = eq
+				}
+
+				break
+			}
+
+			end = eq
+		}
+
+		var an, bn int
+		for _, e := range es[start:end] {
+			if e.op != '+' {
+				an++
+			}
+			if e.op != '-' {
+				bn++
+			}
+		}
+
+		fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(es[start].a, an), hunkRange(es[start].b, bn))
+
+		for _, e := range es[start:end] {
+			switch e.op {
+			case '-':
+				fmt.Fprintf(w, "-%s\n", a[e.a])
+			default:
+				fmt.Fprintf(w, "%c%s\n", e.op, b[e.b])
+			}
+		}
+
+		st = end
+	}
+}
+
+// myers finds the shortest edit script turning a into b.
+// It gives up if more than diffMaxEdits edits are needed
+// as memory grows quadratically with the number of edits.
+func myers(a, b []string) (es []edit, ok bool) {
+	pref := 0
+	for pref < len(a) && pref < len(b) && a[pref] == b[pref] {
+		pref++
+	}
+
+	suff := 0
+	for suff < len(a)-pref && suff < len(b)-pref && a[len(a)-1-suff] == b[len(b)-1-suff] {
+		suff++
+	}
+
+	for i := 0; i < pref; i++ {
+		es = append(es, edit{op: ' ', a: i, b: i})
+	}
+
+	ma, mb := a[pref:len(a)-suff], b[pref:len(b)-suff]
+	n, m := len(ma), len(mb)
+	max := n + m
+	off := max + 1
+
+	v := make([]int, 2*max+3)
+
+	// trace[d] keeps v[k] for k in [-d-1, d+1] before step d.
+	var trace [][]int
+
+	var d int
+loop:
+	for d = 0; d <= max; d++ {
+		if d > diffMaxEdits {
+			return nil, false
+		}
+
+		trace = append(trace, append([]int{}, v[off-d-1:off+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+
+			y := x - k
+
+			for x < n && y < m && ma[x] == mb[y] {
+				x++
+				y++
+			}
+
+			v[off+k] = x
+
+			if x >= n && y >= m {
+				break loop
+			}
+		}
+	}
+
+	mid := make([]edit, 0, n+m)
+	x, y := n, m
+
+	for ; d > 0; d-- {
+		tv := trace[d]
+		at := func(k int) int { return tv[k+d+1] }
+		k := x - y
+
+		var pk int
+		if k == -d || k != d && at(k-1) < at(k+1) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+
+		px := at(pk)
+		py := px - pk
+
+		for x > px && y > py {
+			x--
+			y--
+			mid = append(mid, edit{op: ' ', a: pref + x, b: pref + y})
+		}
+
+		if x == px {
+			y--
+			mid = append(mid, edit{op: '+', a: pref + x, b: pref + y})
+		} else {
+			x--
+			mid = append(mid, edit{op: '-', a: pref + x, b: pref + y})
+		}
+	}
+
+	for x > 0 && y > 0 {
+		x--
+		y--
+		mid = append(mid, edit{op: ' ', a: pref + x, b: pref + y})
+	}
+
+	for i := len(mid) - 1; i >= 0; i-- {
+		es = append(es, mid[i])
+	}
+
+	for i := 0; i < suff; i++ {
+		es = append(es, edit{op: ' ', a: len(a) - suff + i, b: len(b) - suff + i})
+	}
+
+	return es, true
+}
+
+func hunkRange(st, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", st)
+	}
+
+	if n == 1 {
+		return fmt.Sprintf("%d", st+1)
+	}
+
+	return fmt.Sprintf("%d,%d", st+1, n)
+}
+
+func splitLines(s string) []string {
+	l := strings.Split(s, "\n")
+
+	if len(l) > 1 && l[len(l)-1] == "" {
+		l = l[:len(l)-1]
+	}
+
+	return l
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func bytesOf(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+
+	b := make([]byte, v.Len())
+
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+
+	return b
+}
+
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\t' && r != '\r' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CommonPrefix returns the length in bytes of the common prefix of a and b
+// which doesn't split runes.
+func CommonPrefix(a, b string) (i int) {
+	for i < len(a) && i < len(b) {
+		ra, sa := utf8.DecodeRuneInString(a[i:])
+		rb, sb := utf8.DecodeRuneInString(b[i:])
+
+		if ra != rb || sa != sb {
+			break
+		}
+
+		i += sa
+	}
+
+	return i
+}
+
+// Snippet quotes s around byte offset i
+// and returns the column of i in the result.
+// Text far from i is cut and replaced with "...".
+func Snippet(s string, i int) (q string, col int) {
+	st := i
+	for n := 0; st > 0 && n < diffWindow; n++ {
+		_, size := utf8.DecodeLastRuneInString(s[:st])
+		st -= size
+	}
+
+	end := i
+	for n := 0; end < len(s) && n < 2*diffWindow; n++ {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+	}
+
+	if st != 0 {
+		q = "..."
+	}
+
+	col = len(q) + utf8.RuneCountInString(strconv.Quote(s[st:i])) - 1
+
+	q += strconv.Quote(s[st:end])
+
+	if end != len(s) {
+		q += "..."
+	}
+
+	return q, col
+}
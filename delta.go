@@ -0,0 +1,35 @@
+package assert
+
+import "github.com/nikandfor/assert/is"
+
+func InDelta(t TestingT, exp, act interface{}, delta float64, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.InDelta(exp, act, delta), args...)
+}
+
+func InEpsilon(t TestingT, exp, act interface{}, eps float64, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.InEpsilon(exp, act, eps), args...)
+}
+
+func InDeltaSlice(t TestingT, exp, act interface{}, delta float64, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.InDeltaSlice(exp, act, delta), args...)
+}
+
+func InDeltaMapValues(t TestingT, exp, act interface{}, delta float64, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.InDeltaMapValues(exp, act, delta), args...)
+}
@@ -0,0 +1,19 @@
+package assert
+
+import "github.com/nikandfor/assert/is"
+
+func JSONEq(t TestingT, exp, act interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.JSONEq(exp, act), args...)
+}
+
+func XMLEq(t TestingT, exp, act interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.XMLEq(exp, act), args...)
+}
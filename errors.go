@@ -0,0 +1,35 @@
+package assert
+
+import "github.com/nikandfor/assert/is"
+
+func ErrorAs(t TestingT, err error, target interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.ErrorAs(err, target), args...)
+}
+
+func ErrorContains(t TestingT, err error, substr string, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.ErrorContains(err, substr), args...)
+}
+
+func ErrorRegexp(t TestingT, err error, re interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.ErrorRegexp(err, re), args...)
+}
+
+func ErrorType(t TestingT, err error, typ interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.ErrorType(err, typ), args...)
+}
@@ -0,0 +1,136 @@
+// Package golden compares test output with files in testdata directory.
+//
+// Golden files are rewritten with the actual output
+// if the test binary is run with -assert.update flag
+// or ASSERT_UPDATE environment variable is set.
+package golden
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/nikandfor/assert"
+	"github.com/nikandfor/assert/deep"
+	"github.com/nikandfor/assert/is"
+)
+
+type (
+	TestingT = assert.TestingT
+
+	helper interface {
+		Helper()
+	}
+
+	namer interface {
+		Name() string
+	}
+)
+
+// update is namespaced not to clash with -update flags defined by tests.
+var update = flag.Bool("assert.update", false, "update golden files")
+
+// Update reports whether golden files should be rewritten.
+func Update() bool {
+	if *update {
+		return true
+	}
+
+	ok, _ := strconv.ParseBool(os.Getenv("ASSERT_UPDATE"))
+
+	return ok
+}
+
+// Path returns golden file path for the test: testdata/<TestName>/<name>.golden.
+func Path(t TestingT, name string) string {
+	var test string
+
+	if t, ok := t.(namer); ok {
+		test = filepath.FromSlash(t.Name())
+	}
+
+	return filepath.Join("testdata", test, name+".golden")
+}
+
+// Assert compares act with the golden file content.
+func Assert(t TestingT, name string, act []byte, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return assert.Eval(t, File(Path(t, name), act), args...)
+}
+
+// AssertValue compares the deep.Full representation of v with the golden file content.
+func AssertValue(t TestingT, name string, v interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	var b bytes.Buffer
+
+	_, err := deep.FprintWith(&b, v, deep.Full())
+	if err != nil {
+		assert.Fail(t, "Print value: %v", err)
+
+		return false
+	}
+
+	b.WriteByte('\n')
+
+	return Assert(t, name, b.Bytes(), args...)
+}
+
+// File checks act is equal to the file content.
+// In update mode the file is rewritten instead.
+func File(path string, act []byte) is.Checker {
+	return is.CheckerFunc(func(w io.Writer) bool {
+		if Update() {
+			err := write(path, act)
+			if err != nil {
+				fmt.Fprintf(w, "Update golden file: %v", err)
+
+				return false
+			}
+
+			return true
+		}
+
+		exp, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(w, "Golden file %s does not exist, run with -assert.update to create it", path)
+
+			return false
+		}
+		if err != nil {
+			fmt.Fprintf(w, "Read golden file: %v", err)
+
+			return false
+		}
+
+		if bytes.Equal(exp, act) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Golden file %s differs, run with -assert.update to rewrite it\n", path)
+
+		deep.Diff(w, exp, act)
+
+		return false
+	})
+}
+
+func write(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
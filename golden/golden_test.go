@@ -0,0 +1,102 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type (
+	TestT struct {
+		name   string
+		failed bool
+		b      []byte
+	}
+
+	point struct {
+		X, Y int
+	}
+)
+
+func TestGolden(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	defer func() {
+		_ = os.Chdir(wd)
+	}()
+
+	tt := &TestT{name: "TestName/sub"}
+
+	if Assert(tt, "text", []byte("a\nb\n")) || !tt.failed || !strings.Contains(string(tt.b), "does not exist") {
+		t.Errorf("missing file: %v %s", tt.failed, tt.b)
+	}
+
+	*update = true
+
+	tt = &TestT{name: "TestName/sub"}
+
+	ok := Assert(tt, "text", []byte("a\nb\n"))
+	ok = AssertValue(tt, "value", point{X: 1, Y: 2}) && ok
+	ok = AssertValue(tt, "long", strings.Repeat("a", 50)+"b") && ok
+
+	*update = false
+
+	if !ok || tt.failed {
+		t.Errorf("update: %s", tt.b)
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "TestName", "sub", "value.golden"))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	t.Logf("value.golden:\n%s", data)
+
+	tt = &TestT{name: "TestName/sub"}
+
+	if !Assert(tt, "text", []byte("a\nb\n")) || !AssertValue(tt, "value", point{X: 1, Y: 2}) {
+		t.Errorf("compare: %s", tt.b)
+	}
+
+	if AssertValue(tt, "long", strings.Repeat("a", 50)+"c") || !tt.failed {
+		t.Errorf("expected long value to fail")
+	}
+
+	tt = &TestT{name: "TestName/sub"}
+
+	if Assert(tt, "text", []byte("a\nc\n")) || !tt.failed {
+		t.Errorf("expected to fail")
+	}
+
+	exp := `Golden file testdata/TestName/sub/text.golden differs, run with -assert.update to rewrite it
+text differs:
+--- expected
++++ actual
+@@ -1,2 +1,2 @@
+ a
+-b
++c
+`
+
+	if string(tt.b) != exp {
+		t.Errorf("output:\n%s\nexpected:\n%s", tt.b, exp)
+	}
+}
+
+func (tt *TestT) Name() string { return tt.name }
+
+func (tt *TestT) Fail() { tt.failed = true }
+
+func (tt *TestT) Logf(format string, args ...interface{}) {
+	tt.b = append(tt.b, fmt.Sprintf(format, args...)...)
+}
@@ -0,0 +1,262 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/nikandfor/assert/deep"
+)
+
+func Contains(x, elem interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		ok, valid := contains(x, elem)
+		if !valid {
+			fmt.Fprintf(w, "Can't look for %T in %T", elem, x)
+
+			return false
+		}
+
+		if ok {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want %s to contain %s", sprint(x), sprint(elem))
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "%s to contain %s", sprint(x), sprint(elem))
+	})
+}
+
+func NotContains(x, elem interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		ok, valid := contains(x, elem)
+		if !valid {
+			fmt.Fprintf(w, "Can't look for %T in %T", elem, x)
+
+			return false
+		}
+
+		if !ok {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want %s to not contain %s", sprint(x), sprint(elem))
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "%s to not contain %s", sprint(x), sprint(elem))
+	})
+}
+
+func Len(x interface{}, l int) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		r := reflect.ValueOf(x)
+
+		switch r.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		default:
+			fmt.Fprintf(w, "Can't get len of %T", x)
+
+			return false
+		}
+
+		if r.Len() == l {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want len %d, got %d: %s", l, r.Len(), sprint(x))
+
+		return false
+	}), "len %d", l)
+}
+
+func Empty(x interface{}) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if isEmpty(reflect.ValueOf(x)) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want empty, got: %s", sprint(x))
+
+		return false
+	}), "empty")
+}
+
+func NotEmpty(x interface{}) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if !isEmpty(reflect.ValueOf(x)) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want not empty, got: %s", sprint(x))
+
+		return false
+	}), "not empty")
+}
+
+// ElementsMatch checks that exp and act contain the same elements in any order.
+// Duplicates must match in number.
+func ElementsMatch(exp, act interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		e := reflect.ValueOf(exp)
+		a := reflect.ValueOf(act)
+
+		if !isList(e) || !isList(a) {
+			fmt.Fprintf(w, "Want slices or arrays, got: %T and %T", exp, act)
+
+			return false
+		}
+
+		missing, extra := matchElements(e, a)
+		if len(missing) == 0 && len(extra) == 0 {
+			return true
+		}
+
+		fmt.Fprintf(w, "Elements do not match:")
+
+		for _, i := range missing {
+			fmt.Fprintf(w, "\n[%d]: missing in actual: %s", i, sprint(e.Index(i).Interface()))
+		}
+
+		for _, i := range extra {
+			fmt.Fprintf(w, "\n[%d]: extra in actual: %s", i, sprint(a.Index(i).Interface()))
+		}
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "elements matching %s", sprint(exp))
+	})
+}
+
+// Subset checks that every element of sub is contained in list.
+// For maps every key of sub must be in list with the equal value.
+func Subset(list, sub interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		l := reflect.ValueOf(list)
+		s := reflect.ValueOf(sub)
+
+		var missing []string
+
+		switch {
+		case l.Kind() == reflect.Map && s.Kind() == reflect.Map && s.Type().Key().AssignableTo(l.Type().Key()):
+			it := s.MapRange()
+
+			for it.Next() {
+				v := l.MapIndex(it.Key())
+
+				if !v.IsValid() || !deep.Equal(v.Interface(), it.Value().Interface()) {
+					missing = append(missing, fmt.Sprintf("[%s]: %s", sprint(it.Key().Interface()), sprint(it.Value().Interface())))
+				}
+			}
+		case isList(l) && isList(s):
+			for i := 0; i < s.Len(); i++ {
+				if !listContains(l, s.Index(i).Interface()) {
+					missing = append(missing, fmt.Sprintf("[%d]: %s", i, sprint(s.Index(i).Interface())))
+				}
+			}
+		default:
+			fmt.Fprintf(w, "Can't check %T is subset of %T", sub, list)
+
+			return false
+		}
+
+		if len(missing) == 0 {
+			return true
+		}
+
+		fmt.Fprintf(w, "Not a subset, missing in list:")
+
+		for _, m := range missing {
+			fmt.Fprintf(w, "\n%s", m)
+		}
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "superset of %s", sprint(sub))
+	})
+}
+
+func contains(x, elem interface{}) (found, valid bool) {
+	r := reflect.ValueOf(x)
+
+	switch r.Kind() {
+	case reflect.String:
+		e := reflect.ValueOf(elem)
+		if e.Kind() != reflect.String {
+			return false, false
+		}
+
+		return strings.Contains(r.String(), e.String()), true
+	case reflect.Slice, reflect.Array:
+		return listContains(r, elem), true
+	case reflect.Map:
+		it := r.MapRange()
+
+		for it.Next() {
+			if deep.Equal(it.Key().Interface(), elem) {
+				return true, true
+			}
+		}
+
+		return false, true
+	}
+
+	return false, false
+}
+
+func listContains(l reflect.Value, elem interface{}) bool {
+	for i := 0; i < l.Len(); i++ {
+		if deep.Equal(l.Index(i).Interface(), elem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchElements(e, a reflect.Value) (missing, extra []int) {
+	used := make([]bool, a.Len())
+
+outer:
+	for i := 0; i < e.Len(); i++ {
+		for j := 0; j < a.Len(); j++ {
+			if used[j] || !deep.Equal(e.Index(i).Interface(), a.Index(j).Interface()) {
+				continue
+			}
+
+			used[j] = true
+
+			continue outer
+		}
+
+		missing = append(missing, i)
+	}
+
+	for j, u := range used {
+		if !u {
+			extra = append(extra, j)
+		}
+	}
+
+	return
+}
+
+func isEmpty(r reflect.Value) bool {
+	switch r.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return r.Len() == 0
+	case reflect.Ptr:
+		return r.IsNil() || isEmpty(r.Elem())
+	}
+
+	return r.IsZero()
+}
+
+func isList(r reflect.Value) bool {
+	return r.Kind() == reflect.Slice || r.Kind() == reflect.Array
+}
@@ -0,0 +1,262 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nikandfor/assert/deep"
+)
+
+type (
+	// Describer is implemented by checkers which can describe
+	// their positive expectation, like "nil" or "equal to 5".
+	// It's used by Not to explain what was not expected.
+	Describer interface {
+		Describe(w io.Writer)
+	}
+
+	described struct {
+		Checker
+
+		desc func(w io.Writer)
+	}
+
+	not struct {
+		c Checker
+	}
+
+	// child buffers the output of the i-th child checker
+	// and names its arguments using the parent writer.
+	child struct {
+		bytes.Buffer
+
+		w io.Writer
+		i int
+	}
+)
+
+const indent = "    "
+
+// Described adds description of the positive expectation to c.
+func Described(c Checker, format string, args ...interface{}) Checker {
+	return describe(c, func(w io.Writer) {
+		fmt.Fprintf(w, format, args...)
+	})
+}
+
+// Not inverts c.
+func Not(c Checker) Checker {
+	return not{c: c}
+}
+
+// And checks cs in order until the first failure, which is reported.
+func And(cs ...Checker) Checker {
+	return CheckerFunc(func(w io.Writer) bool {
+		for i, c := range cs {
+			b := &child{w: w, i: i}
+
+			if c.Check(b) {
+				continue
+			}
+
+			fmt.Fprintf(w, "Check failed:\n")
+			writeChild(w, i, b.Bytes())
+
+			return false
+		}
+
+		return true
+	})
+}
+
+// Or checks cs in order until the first success.
+// All failures are reported if none succeeded.
+func Or(cs ...Checker) Checker {
+	return CheckerFunc(func(w io.Writer) bool {
+		var b bytes.Buffer
+
+		for i, c := range cs {
+			cb := &child{w: w, i: i}
+
+			if c.Check(cb) {
+				return true
+			}
+
+			writeChild(&b, i, cb.Bytes())
+		}
+
+		fmt.Fprintf(w, "None of %d checks passed:\n%s", len(cs), b.Bytes())
+
+		return false
+	})
+}
+
+// AllOf checks all of cs and reports every failure.
+func AllOf(cs ...Checker) Checker {
+	return CheckerFunc(func(w io.Writer) bool {
+		var b bytes.Buffer
+		failed := 0
+
+		for i, c := range cs {
+			cb := &child{w: w, i: i}
+
+			if c.Check(cb) {
+				continue
+			}
+
+			writeChild(&b, i, cb.Bytes())
+			failed++
+		}
+
+		if failed == 0 {
+			return true
+		}
+
+		fmt.Fprintf(w, "%d of %d checks failed:\n%s", failed, len(cs), b.Bytes())
+
+		return false
+	})
+}
+
+// AnyOf is like Or but checks all of cs even if one succeeded.
+func AnyOf(cs ...Checker) Checker {
+	return CheckerFunc(func(w io.Writer) bool {
+		var b bytes.Buffer
+		ok := false
+
+		for i, c := range cs {
+			cb := &child{w: w, i: i}
+
+			if c.Check(cb) {
+				ok = true
+				continue
+			}
+
+			writeChild(&b, i, cb.Bytes())
+		}
+
+		if ok {
+			return true
+		}
+
+		fmt.Fprintf(w, "None of %d checks passed:\n%s", len(cs), b.Bytes())
+
+		return false
+	})
+}
+
+// NoneOf checks all of cs fail and reports those which passed.
+func NoneOf(cs ...Checker) Checker {
+	return CheckerFunc(func(w io.Writer) bool {
+		var b bytes.Buffer
+		passed := 0
+
+		for i, c := range cs {
+			cb := &child{w: w, i: i}
+
+			if !c.Check(cb) {
+				continue
+			}
+
+			writeNot(cb, c)
+
+			writeChild(&b, i, cb.Bytes())
+			passed++
+		}
+
+		if passed == 0 {
+			return true
+		}
+
+		fmt.Fprintf(w, "%d of %d checks passed:\n%s", passed, len(cs), b.Bytes())
+
+		return false
+	})
+}
+
+func (n not) Check(w io.Writer) bool {
+	if !n.c.Check(io.Discard) {
+		return true
+	}
+
+	writeNot(w, n.c)
+
+	return false
+}
+
+func (n not) Describe(w io.Writer) {
+	d, ok := n.c.(Describer)
+	if !ok {
+		fmt.Fprintf(w, "check to fail")
+
+		return
+	}
+
+	fmt.Fprintf(w, "not ")
+	d.Describe(w)
+}
+
+func (d described) Describe(w io.Writer) { d.desc(w) }
+
+func describe(c Checker, desc func(w io.Writer)) Checker {
+	return described{Checker: c, desc: desc}
+}
+
+func (c *child) ArgName(i int) string {
+	if n := c.namer(); n != nil {
+		return n.ArgName(i)
+	}
+
+	return ""
+}
+
+func (c *child) Nested(i int) ArgNamer {
+	if n, ok := c.namer().(NestedArgNamer); ok {
+		return n.Nested(i)
+	}
+
+	return nil
+}
+
+func (c *child) Deadline() (time.Time, bool) {
+	if d, ok := c.w.(Deadliner); ok {
+		return d.Deadline()
+	}
+
+	return time.Time{}, false
+}
+
+func (c *child) namer() ArgNamer {
+	if n, ok := c.w.(NestedArgNamer); ok {
+		return n.Nested(c.i)
+	}
+
+	return nil
+}
+
+func writeNot(w io.Writer, c Checker) {
+	d, ok := c.(Describer)
+	if !ok {
+		fmt.Fprintf(w, "Want check to fail, but it passed")
+
+		return
+	}
+
+	fmt.Fprintf(w, "Want not ")
+	d.Describe(w)
+}
+
+// writeChild writes output of the i-th child checker as an indented tree node.
+func writeChild(w io.Writer, i int, out []byte) {
+	fmt.Fprintf(w, "%s[%d] ", indent, i)
+
+	pw := deep.NewPrefixWriter(w, indent+indent, false)
+
+	_, _ = pw.Write(out)
+
+	if len(out) == 0 || out[len(out)-1] != '\n' {
+		fmt.Fprintf(w, "\n")
+	}
+}
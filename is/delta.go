@@ -0,0 +1,198 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// InDelta checks |exp - act| <= delta.
+// exp and act may be of any integer or float type.
+// NaN is equal to NaN, infinities are equal only to themselves.
+func InDelta(exp, act interface{}, delta float64) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		e, ok1 := toFloat(reflect.ValueOf(exp))
+		a, ok2 := toFloat(reflect.ValueOf(act))
+		if !ok1 || !ok2 {
+			fmt.Fprintf(w, "Want numbers, got: %T and %T", exp, act)
+
+			return false
+		}
+
+		if inDelta(e, a, delta) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want |%v - %v| <= %v, got delta %v", e, a, delta, math.Abs(e-a))
+
+		return false
+	}), "within %v of %v", delta, exp)
+}
+
+// InEpsilon checks relative error |exp - act| / |exp| <= eps.
+func InEpsilon(exp, act interface{}, eps float64) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		e, ok1 := toFloat(reflect.ValueOf(exp))
+		a, ok2 := toFloat(reflect.ValueOf(act))
+		if !ok1 || !ok2 {
+			fmt.Fprintf(w, "Want numbers, got: %T and %T", exp, act)
+
+			return false
+		}
+
+		if e == a || math.IsNaN(e) && math.IsNaN(a) {
+			return true
+		}
+
+		if math.IsInf(e, 0) || math.IsInf(a, 0) {
+			fmt.Fprintf(w, "Want relative error of %v and %v <= %v", e, a, eps)
+
+			return false
+		}
+
+		rel := math.Abs(e-a) / math.Abs(e)
+		if rel <= eps {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want relative error of %v and %v <= %v, got %v (delta %v)", e, a, eps, rel, math.Abs(e-a))
+
+		return false
+	}), "within relative error %v of %v", eps, exp)
+}
+
+// InDeltaSlice checks InDelta for each pair of elements.
+func InDeltaSlice(exp, act interface{}, delta float64) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		e := reflect.ValueOf(exp)
+		a := reflect.ValueOf(act)
+
+		if !isList(e) || !isList(a) {
+			fmt.Fprintf(w, "Want slices or arrays, got: %T and %T", exp, act)
+
+			return false
+		}
+
+		if e.Len() != a.Len() {
+			fmt.Fprintf(w, "Want len %d, got %d", e.Len(), a.Len())
+
+			return false
+		}
+
+		ok := true
+
+		for i := 0; i < e.Len(); i++ {
+			ok = checkDelta(w, fmt.Sprintf("[%d]", i), e.Index(i), a.Index(i), delta, ok)
+		}
+
+		return ok
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "elements within %v of %s", delta, sprint(exp))
+	})
+}
+
+// InDeltaMapValues checks maps have the same keys and InDelta values.
+func InDeltaMapValues(exp, act interface{}, delta float64) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		e := reflect.ValueOf(exp)
+		a := reflect.ValueOf(act)
+
+		if e.Kind() != reflect.Map || a.Kind() != reflect.Map {
+			fmt.Fprintf(w, "Want maps, got: %T and %T", exp, act)
+
+			return false
+		}
+
+		ok := true
+
+		keys := e.MapKeys()
+
+		for _, k := range a.MapKeys() {
+			if !e.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+		})
+
+		for _, k := range keys {
+			path := fmt.Sprintf("[%#v]", k)
+
+			ev := e.MapIndex(k)
+			av := a.MapIndex(k)
+
+			switch {
+			case !av.IsValid():
+				ok = report(w, ok, "%s: missing in actual", path)
+			case !ev.IsValid():
+				ok = report(w, ok, "%s: extra in actual", path)
+			default:
+				ok = checkDelta(w, path, ev, av, delta, ok)
+			}
+		}
+
+		return ok
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "values within %v of %s", delta, sprint(exp))
+	})
+}
+
+func checkDelta(w io.Writer, path string, e, a reflect.Value, delta float64, ok bool) bool {
+	ef, ok1 := toFloat(e)
+	af, ok2 := toFloat(a)
+
+	switch {
+	case !ok1 || !ok2:
+		return report(w, ok, "%s: want numbers, got: %v and %v", path, e.Type(), a.Type())
+	case !inDelta(ef, af, delta):
+		return report(w, ok, "%s: |%v - %v| > %v (delta %v)", path, ef, af, delta, math.Abs(ef-af))
+	}
+
+	return ok
+}
+
+// report writes a line of a multi-line failure message.
+func report(w io.Writer, ok bool, format string, args ...interface{}) bool {
+	if !ok {
+		fmt.Fprintf(w, "\n")
+	}
+
+	fmt.Fprintf(w, format, args...)
+
+	return false
+}
+
+// inDelta treats NaN as equal to NaN, as InEpsilon does.
+// Infinities are only equal to themselves whatever delta is.
+func inDelta(e, a, delta float64) bool {
+	if e == a || math.IsNaN(e) && math.IsNaN(a) {
+		return true
+	}
+
+	if math.IsInf(e, 0) || math.IsInf(a, 0) {
+		return false
+	}
+
+	return math.Abs(e-a) <= delta
+}
+
+func toFloat(v reflect.Value) (float64, bool) {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+
+	return 0, false
+}
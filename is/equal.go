@@ -10,7 +10,13 @@ import (
 )
 
 func Equal(a, b interface{}) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return EqualWith(a, b)
+}
+
+// EqualWith is Equal with deep comparison options.
+// Printing options like deep.UseStringer apply to the failure output.
+func EqualWith(a, b interface{}, opts ...deep.Option) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
 		var buf bytes.Buffer
 
 		defer func() {
@@ -23,22 +29,26 @@ func Equal(a, b interface{}) Checker {
 			fmt.Fprintf(w, "%s", debug.Stack())
 		}()
 
-		eq := deep.Diff(&buf, a, b)
+		eq := deep.DiffWith(&buf, a, b, opts...)
 		if eq {
 			return true
 		}
 
 		//	fmt.Fprintf(w, "Not equal:\nExpected: %#v\nActual:   %#v\nDiff:\n%s", a, b, buf.Bytes())
-		fmt.Fprintf(w, "Not equal:\nExpected: ")
+		fmt.Fprintf(w, "Not equal:\nExpected%s: ", argName(w, 0))
 
-		_, err := deep.Fprint(w, a)
+		_, err := deep.FprintWith(w, a, opts...)
 		if err != nil {
 			fmt.Fprintf(w, "PRINT ERROR: %v\n", err)
 		}
 
-		fmt.Fprintf(w, "\nActual:   ")
+		if n := argName(w, 1); n != "" {
+			fmt.Fprintf(w, "\nActual%s: ", n)
+		} else {
+			fmt.Fprintf(w, "\nActual:   ")
+		}
 
-		_, err = deep.Fprint(w, b)
+		_, err = deep.FprintWith(w, b, opts...)
 		if err != nil {
 			fmt.Fprintf(w, "PRINT ERROR: %v\n", err)
 		}
@@ -46,11 +56,15 @@ func Equal(a, b interface{}) Checker {
 		fmt.Fprintf(w, "\nDiff:\n%s", buf.Bytes())
 
 		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "equal to ")
+
+		_, _ = deep.FprintWith(w, a, opts...)
 	})
 }
 
 func NotEqual(a, b interface{}) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return describe(CheckerFunc(func(w io.Writer) bool {
 		var buf bytes.Buffer
 
 		defer func() {
@@ -69,7 +83,7 @@ func NotEqual(a, b interface{}) Checker {
 		}
 
 		//	fmt.Fprintf(w, "Not equal:\nExpected: %#v\nActual:   %#v\nDiff:\n%s", a, b, buf.Bytes())
-		fmt.Fprintf(w, "Expected not equal: ")
+		fmt.Fprintf(w, "Expected not equal%s: ", argName(w, 1))
 
 		_, err := deep.Fprint(w, a)
 		if err != nil {
@@ -77,5 +91,9 @@ func NotEqual(a, b interface{}) Checker {
 		}
 
 		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "not equal to ")
+
+		_, _ = deep.Fprint(w, a)
 	})
 }
@@ -0,0 +1,208 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ErrorAs checks errors.As(err, target).
+// target must be a non-nil pointer to an interface or to a type implementing error.
+func ErrorAs(err error, target interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		r := reflect.ValueOf(target)
+		if r.Kind() != reflect.Ptr || r.IsNil() {
+			fmt.Fprintf(w, "Target must be a non-nil pointer, got: %T", target)
+
+			return false
+		}
+
+		if e := r.Type().Elem(); e.Kind() != reflect.Interface && !e.Implements(errorType) {
+			fmt.Fprintf(w, "Target must be a pointer to an interface or to a type implementing error, got: %T", target)
+
+			return false
+		}
+
+		if err != nil && errors.As(err, target) {
+			return true
+		}
+
+		if err == nil {
+			fmt.Fprintf(w, "Want error as %v", r.Type().Elem())
+
+			return false
+		}
+
+		writeChain(w, err)
+
+		fmt.Fprintf(w, "has no %v", r.Type().Elem())
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "error as %T", target)
+	})
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func ErrorContains(err error, substr string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if err != nil && strings.Contains(err.Error(), substr) {
+			return true
+		}
+
+		if err == nil {
+			fmt.Fprintf(w, "Want error containing %q", substr)
+
+			return false
+		}
+
+		writeChain(w, err)
+
+		fmt.Fprintf(w, "does not contain %q", substr)
+
+		return false
+	}), "error containing %q", substr)
+}
+
+// ErrorRegexp checks error text matches re.
+// re is a string or *regexp.Regexp.
+func ErrorRegexp(err error, re interface{}) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		rx, ok := compileRegexp(w, re)
+		if !ok {
+			return false
+		}
+
+		if err != nil && rx.MatchString(err.Error()) {
+			return true
+		}
+
+		if err == nil {
+			fmt.Fprintf(w, "Want error matching %q", rx)
+
+			return false
+		}
+
+		writeChain(w, err)
+
+		fmt.Fprintf(w, "does not match %q", rx)
+
+		return false
+	}), "error matching %v", re)
+}
+
+// ErrorType checks there is an error of the given concrete type anywhere in the err tree.
+// typ is a reflect.Type or a value of that type.
+func ErrorType(err error, typ interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		tp, ok := typ.(reflect.Type)
+		if !ok {
+			tp = reflect.TypeOf(typ)
+		}
+
+		found := false
+
+		walkErrors(err, func(e error) bool {
+			found = reflect.TypeOf(e) == tp
+
+			return !found
+		})
+
+		if found {
+			return true
+		}
+
+		if err == nil {
+			fmt.Fprintf(w, "Want error of type %v", tp)
+
+			return false
+		}
+
+		writeChain(w, err)
+
+		fmt.Fprintf(w, "has no error of type %v", tp)
+
+		return false
+	}), func(w io.Writer) {
+		if tp, ok := typ.(reflect.Type); ok {
+			fmt.Fprintf(w, "error of type %v", tp)
+		} else {
+			fmt.Fprintf(w, "error of type %T", typ)
+		}
+	})
+}
+
+func writeChain(w io.Writer, err error) {
+	fmt.Fprintf(w, "Error chain\n")
+
+	writeTree(w, err, 0)
+}
+
+func writeTree(w io.Writer, err error, d int) {
+	for e := err; e != nil; {
+		fmt.Fprintf(w, "%s%q (type %T)\n", strings.Repeat("    ", d), e.Error(), e)
+
+		switch u := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				writeTree(w, e, d+1)
+			}
+
+			return
+		case interface{ Unwrap() error }:
+			e = u.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// walkErrors calls f for each error in the tree in pre-order until f returns false.
+func walkErrors(err error, f func(error) bool) bool {
+	for e := err; e != nil; {
+		if !f(e) {
+			return false
+		}
+
+		switch u := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if !walkErrors(e, f) {
+					return false
+				}
+			}
+
+			return true
+		case interface{ Unwrap() error }:
+			e = u.Unwrap()
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+func compileRegexp(w io.Writer, re interface{}) (*regexp.Regexp, bool) {
+	switch re := re.(type) {
+	case *regexp.Regexp:
+		return re, true
+	case string:
+		rx, err := regexp.Compile(re)
+		if err != nil {
+			fmt.Fprintf(w, "Bad regexp: %v", err)
+
+			return nil, false
+		}
+
+		return rx, true
+	}
+
+	fmt.Fprintf(w, "Want regexp or string, got: %T", re)
+
+	return nil, false
+}
@@ -0,0 +1,39 @@
+package is
+
+import "cmp"
+
+func EqualOf[T any](exp, act T) Checker {
+	return Equal(exp, act)
+}
+
+func NotEqualOf[T any](exp, act T) Checker {
+	return NotEqual(exp, act)
+}
+
+func LessOf[T cmp.Ordered](a, b T) Checker {
+	return Less(a, b)
+}
+
+func GreaterOf[T cmp.Ordered](a, b T) Checker {
+	return Greater(a, b)
+}
+
+func InRangeOf[T cmp.Ordered](x, lo, hi T) Checker {
+	return Between(x, lo, hi)
+}
+
+func LessOrEqualOf[T cmp.Ordered](a, b T) Checker {
+	return LessOrEqual(a, b)
+}
+
+func GreaterOrEqualOf[T cmp.Ordered](a, b T) Checker {
+	return GreaterOrEqual(a, b)
+}
+
+func PositiveOf[T cmp.Ordered](x T) Checker {
+	return Positive(x)
+}
+
+func NegativeOf[T cmp.Ordered](x T) Checker {
+	return Negative(x)
+}
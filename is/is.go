@@ -1,10 +1,13 @@
 package is
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
+
+	"github.com/nikandfor/assert/deep"
 )
 
 type (
@@ -14,37 +17,51 @@ type (
 
 	CheckerFunc func(w io.Writer) bool
 
+	// ArgNamer is implemented by writers which know
+	// source expressions of the checked arguments.
+	ArgNamer interface {
+		ArgName(i int) string
+	}
+
+	// NestedArgNamer is an ArgNamer which also knows arguments
+	// of the checkers passed as arguments, like x in Or(Nil(x), ...).
+	NestedArgNamer interface {
+		ArgNamer
+
+		Nested(i int) ArgNamer
+	}
+
 	equal struct {
 		a, b interface{}
 	}
 )
 
 func True(ok bool) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if ok {
 			return true
 		}
 
-		fmt.Fprintf(w, "Want true")
+		fmt.Fprintf(w, "Want true%s", argName(w, 0))
 
 		return false
-	})
+	}), "true")
 }
 
 func False(ok bool) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if !ok {
 			return true
 		}
 
-		fmt.Fprintf(w, "Want false")
+		fmt.Fprintf(w, "Want false%s", argName(w, 0))
 
 		return false
-	})
+	}), "false")
 }
 
 func Nil(x interface{}) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if x == nil {
 			return true
 		}
@@ -55,26 +72,26 @@ func Nil(x interface{}) Checker {
 			return r.IsNil()
 		}
 
-		fmt.Fprintf(w, "Want nil, got: %v", x)
+		fmt.Fprintf(w, "Want nil%s, got: %v", argName(w, 0), x)
 
 		return false
-	})
+	}), "nil")
 }
 
 func NotNil(x interface{}) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if x != nil {
 			return true
 		}
 
-		fmt.Fprintf(w, "Want not nil")
+		fmt.Fprintf(w, "Want not nil%s", argName(w, 0))
 
 		return false
-	})
+	}), "not nil")
 }
 
 func NoError(err error) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if err == nil {
 			return true
 		}
@@ -82,11 +99,11 @@ func NoError(err error) Checker {
 		fmt.Fprintf(w, "Error: %+v (type: %[1]T)", err)
 
 		return false
-	})
+	}), "no error")
 }
 
 func Error(err error) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if err != nil {
 			return true
 		}
@@ -94,11 +111,11 @@ func Error(err error) Checker {
 		fmt.Fprintf(w, "Want error")
 
 		return false
-	})
+	}), "error")
 }
 
 func ErrorIs(err, target error) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if errors.Is(err, target) {
 			return true
 		}
@@ -109,20 +126,16 @@ func ErrorIs(err, target error) Checker {
 			return false
 		}
 
-		fmt.Fprintf(w, "Error chain\n")
-
-		for e := err; e != nil; e = errors.Unwrap(e) {
-			fmt.Fprintf(w, "%q (type %T)\n", e.Error(), e)
-		}
+		writeChain(w, err)
 
 		fmt.Fprintf(w, "is not %q (type %T)", target.Error(), target)
 
 		return false
-	})
+	}), "error is %q", target)
 }
 
 func Zero(x interface{}) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		if x == nil {
 			return true
 		}
@@ -136,11 +149,11 @@ func Zero(x interface{}) Checker {
 		fmt.Fprintf(w, "Want zero value, got: %v", x)
 
 		return false
-	})
+	}), "zero value")
 }
 
 func NotZero(x interface{}) Checker {
-	return CheckerFunc(func(w io.Writer) bool {
+	return Described(CheckerFunc(func(w io.Writer) bool {
 		r := reflect.ValueOf(x)
 
 		if !r.IsZero() {
@@ -150,7 +163,33 @@ func NotZero(x interface{}) Checker {
 		fmt.Fprintf(w, "Want not zero value, got: %v", x)
 
 		return false
-	})
+	}), "not zero value")
 }
 
 func (f CheckerFunc) Check(w io.Writer) bool { return f(w) }
+
+func argName(w io.Writer, i int) string {
+	n, ok := w.(ArgNamer)
+	if !ok {
+		return ""
+	}
+
+	name := n.ArgName(i)
+	if name == "" {
+		return ""
+	}
+
+	return " (" + name + ")"
+}
+
+// sprint prints x with deep.Fprint.
+func sprint(x interface{}) string {
+	var b bytes.Buffer
+
+	_, err := deep.Fprint(&b, x)
+	if err != nil {
+		fmt.Fprintf(&b, "PRINT ERROR: %v", err)
+	}
+
+	return b.String()
+}
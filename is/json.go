@@ -0,0 +1,251 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nikandfor/assert/deep"
+)
+
+// JSONEq checks exp and act are semantically equal JSON documents.
+// []byte, string and io.Reader arguments are decoded as JSON,
+// other values are marshaled first.
+// Big integers are compared exactly, opts apply to other numbers.
+// Differences are reported with JSON Pointer paths.
+func JSONEq(exp, act interface{}, opts ...deep.Option) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		e, err := decodeJSON(exp)
+		if err != nil {
+			fmt.Fprintf(w, "Expected%s: %v", argName(w, 0), err)
+
+			return false
+		}
+
+		a, err := decodeJSON(act)
+		if err != nil {
+			fmt.Fprintf(w, "Actual%s: %v", argName(w, 1), err)
+
+			return false
+		}
+
+		var buf bytes.Buffer
+
+		if jsonEqual(&buf, nil, e, a, opts) {
+			return true
+		}
+
+		fmt.Fprintf(w, "JSON not equal:\n%s", buf.Bytes())
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "JSON equal to %s", docString(exp))
+	})
+}
+
+func decodeJSON(x interface{}) (v interface{}, err error) {
+	var r io.Reader
+
+	switch x := x.(type) {
+	case []byte:
+		r = bytes.NewReader(x)
+	case json.RawMessage:
+		r = bytes.NewReader(x)
+	case string:
+		r = strings.NewReader(x)
+	case io.Reader:
+		r = x
+	default:
+		data, err := json.Marshal(x)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %T: %w", x, err)
+		}
+
+		r = bytes.NewReader(data)
+	}
+
+	d := json.NewDecoder(r)
+	d.UseNumber()
+
+	err = d.Decode(&v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var tail json.RawMessage
+
+	if err = d.Decode(&tail); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid JSON: data after the value")
+	}
+
+	return jsonNumbers(v), nil
+}
+
+// jsonNumbers replaces numbers with float64
+// except integers which can't be represented exactly, they are left as json.Number.
+func jsonNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, x := range v {
+			v[k] = jsonNumbers(x)
+		}
+	case []interface{}:
+		for i, x := range v {
+			v[i] = jsonNumbers(x)
+		}
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return v
+		}
+
+		r, ok := new(big.Rat).SetString(string(v))
+		if ok && r.IsInt() && r.Cmp(new(big.Rat).SetFloat64(f)) != 0 {
+			return v
+		}
+
+		return f
+	}
+
+	return v
+}
+
+func jsonEqual(w io.Writer, path []string, e, a interface{}, opts []deep.Option) (eq bool) {
+	switch e := e.(type) {
+	case map[string]interface{}:
+		a, ok := a.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(e)+len(a))
+
+		for k := range e {
+			keys = append(keys, k)
+		}
+
+		for k := range a {
+			if _, ok := e[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		eq = true
+
+		for _, k := range keys {
+			ev, eok := e[k]
+			av, aok := a[k]
+			p := append(path, k)
+
+			switch {
+			case !aok:
+				jsonDiff(w, p, "missing in actual: %s", jsonString(ev))
+			case !eok:
+				jsonDiff(w, p, "extra in actual: %s", jsonString(av))
+			default:
+				eq = jsonEqual(w, p, ev, av, opts) && eq
+				continue
+			}
+
+			eq = false
+		}
+
+		return eq
+	case []interface{}:
+		a, ok := a.([]interface{})
+		if !ok {
+			break
+		}
+
+		eq = len(e) == len(a)
+
+		for i := 0; i < len(e) || i < len(a); i++ {
+			p := append(path, strconv.Itoa(i))
+
+			switch {
+			case i >= len(a):
+				jsonDiff(w, p, "missing in actual: %s", jsonString(e[i]))
+			case i >= len(e):
+				jsonDiff(w, p, "extra in actual: %s", jsonString(a[i]))
+			default:
+				eq = jsonEqual(w, p, e[i], a[i], opts) && eq
+			}
+		}
+
+		return eq
+	case json.Number:
+		a, ok := a.(json.Number)
+		if !ok {
+			break
+		}
+
+		x, _ := new(big.Rat).SetString(string(e))
+		y, _ := new(big.Rat).SetString(string(a))
+
+		if x != nil && y != nil && x.Cmp(y) == 0 {
+			return true
+		}
+	default:
+		if deep.EqualWith(e, a, opts...) {
+			return true
+		}
+	}
+
+	jsonDiff(w, path, "%s != %s", jsonString(e), jsonString(a))
+
+	return false
+}
+
+func jsonDiff(w io.Writer, path []string, format string, args ...interface{}) {
+	if len(path) != 0 {
+		fmt.Fprintf(w, "%s: ", jsonPointer(path))
+	}
+
+	fmt.Fprintf(w, format+"\n", args...)
+}
+
+func jsonPointer(path []string) string {
+	var b strings.Builder
+
+	for _, p := range path {
+		p = strings.ReplaceAll(p, "~", "~0")
+		p = strings.ReplaceAll(p, "/", "~1")
+
+		b.WriteByte('/')
+		b.WriteString(p)
+	}
+
+	return b.String()
+}
+
+func jsonString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(data)
+}
+
+// docString is the expected document as written.
+// Readers are already consumed, so they are not printed.
+func docString(x interface{}) string {
+	switch x := x.(type) {
+	case string:
+		return x
+	case []byte:
+		return string(x)
+	case io.Reader:
+		return "the expected document"
+	}
+
+	return sprint(x)
+}
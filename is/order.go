@@ -0,0 +1,294 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/nikandfor/assert/deep"
+)
+
+const unordered = 2
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Greater checks a > b.
+// Arguments may be of any integer, float or string kind or time.Time.
+func Greater(a, b interface{}) Checker {
+	return order(a, b, ">", func(c int) bool { return c > 0 })
+}
+
+func GreaterOrEqual(a, b interface{}) Checker {
+	return order(a, b, ">=", func(c int) bool { return c >= 0 })
+}
+
+func Less(a, b interface{}) Checker {
+	return order(a, b, "<", func(c int) bool { return c < 0 })
+}
+
+func LessOrEqual(a, b interface{}) Checker {
+	return order(a, b, "<=", func(c int) bool { return c <= 0 })
+}
+
+// Between checks lo <= x <= hi.
+// It fails if lo > hi.
+func Between(x, lo, hi interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		cl, dl, ok1 := compareValues(reflect.ValueOf(x), reflect.ValueOf(lo))
+		ch, dh, ok2 := compareValues(reflect.ValueOf(x), reflect.ValueOf(hi))
+		cr, _, ok3 := compareValues(reflect.ValueOf(lo), reflect.ValueOf(hi))
+		if !ok1 || !ok2 || !ok3 {
+			fmt.Fprintf(w, "Can't compare %T with %T and %T", x, lo, hi)
+
+			return false
+		}
+
+		if cr > 0 {
+			fmt.Fprintf(w, "Bad range: ")
+			writeValue(w, lo)
+			fmt.Fprintf(w, " > ")
+			writeValue(w, hi)
+
+			return false
+		}
+
+		if cl >= 0 && cl != unordered && ch <= 0 {
+			return true
+		}
+
+		writeValue(w, x)
+		fmt.Fprintf(w, " is not in [")
+		writeValue(w, lo)
+		fmt.Fprintf(w, ", ")
+		writeValue(w, hi)
+		fmt.Fprintf(w, "]")
+
+		switch {
+		case cl == unordered:
+		case cl < 0:
+			writeDistance(w, " (below by ", dl)
+		default:
+			writeDistance(w, " (above by ", dh)
+		}
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "in [")
+		writeValue(w, lo)
+		fmt.Fprintf(w, ", ")
+		writeValue(w, hi)
+		fmt.Fprintf(w, "]")
+	})
+}
+
+// Positive checks x is greater than zero value of its type.
+func Positive(x interface{}) Checker {
+	return Greater(x, zero(x))
+}
+
+// Negative checks x is less than zero value of its type.
+func Negative(x interface{}) Checker {
+	return Less(x, zero(x))
+}
+
+// Finite checks x is a number and not NaN or Inf.
+func Finite(x interface{}) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		f, ok := toFloat(reflect.ValueOf(x))
+		if !ok {
+			fmt.Fprintf(w, "Want number, got: %T", x)
+
+			return false
+		}
+
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want finite number, got: %v", f)
+
+		return false
+	}), "finite number")
+}
+
+func NaN(x interface{}) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		f, ok := toFloat(reflect.ValueOf(x))
+		if ok && math.IsNaN(f) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want NaN, got: ")
+		writeValue(w, x)
+
+		return false
+	}), "NaN")
+}
+
+func order(a, b interface{}, rel string, ok func(c int) bool) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		c, d, valid := compareValues(reflect.ValueOf(a), reflect.ValueOf(b))
+		if !valid {
+			fmt.Fprintf(w, "Can't compare %T and %T", a, b)
+
+			return false
+		}
+
+		if c != unordered && ok(c) {
+			return true
+		}
+
+		writeValue(w, a)
+		fmt.Fprintf(w, " is not %s ", rel)
+		writeValue(w, b)
+
+		if c != unordered && c != 0 {
+			writeDistance(w, " (by ", d)
+		}
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "%s ", rel)
+		writeValue(w, b)
+	})
+}
+
+// compareValues returns the sign of a - b or unordered for NaNs
+// and the distance between a and b if it makes sense.
+func compareValues(a, b reflect.Value) (c int, d interface{}, ok bool) {
+	for a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+
+	for b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		return 0, nil, false
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		ta := a.Interface().(time.Time)
+		tb := b.Interface().(time.Time)
+
+		d := ta.Sub(tb)
+		if d < 0 {
+			d = -d
+		}
+
+		return ta.Compare(tb), d, true
+	}
+
+	if ia, ok := toBigInt(a); ok {
+		if ib, ok := toBigInt(b); ok {
+			d := new(big.Int).Sub(ia, ib)
+			d.Abs(d)
+
+			return ia.Cmp(ib), intDistance(d, a.Type(), b.Type()), true
+		}
+	}
+
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			switch {
+			case math.IsNaN(fa) || math.IsNaN(fb):
+				return unordered, nil, true
+			case fa < fb:
+				c = -1
+			case fa > fb:
+				c = 1
+			}
+
+			return c, math.Abs(fa - fb), true
+		}
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), nil, true
+	}
+
+	return 0, nil, false
+}
+
+func toBigInt(v reflect.Value) (*big.Int, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), true
+	}
+
+	return nil, false
+}
+
+// intDistance returns d of the same type as arguments if possible.
+func intDistance(d *big.Int, a, b reflect.Type) interface{} {
+	if a != b {
+		return d
+	}
+
+	v := reflect.New(a).Elem()
+
+	switch {
+	case v.CanInt() && d.IsInt64() && !v.OverflowInt(d.Int64()):
+		v.SetInt(d.Int64())
+	case v.CanUint() && d.IsUint64():
+		v.SetUint(d.Uint64())
+	default:
+		return d
+	}
+
+	return v.Interface()
+}
+
+func zero(x interface{}) interface{} {
+	if x == nil {
+		return nil
+	}
+
+	return reflect.Zero(reflect.TypeOf(x)).Interface()
+}
+
+// writeValue prints numbers in decimal and times and durations
+// the way the time checkers do. Other values are printed with deep.Fprint.
+func writeValue(w io.Writer, x interface{}) {
+	switch x := x.(type) {
+	case *big.Int, time.Duration:
+		fmt.Fprintf(w, "%v", x)
+
+		return
+	case time.Time:
+		fmt.Fprintf(w, "%v", x.Format(time.RFC3339Nano))
+
+		return
+	}
+
+	switch v := reflect.ValueOf(x); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		fmt.Fprintf(w, "%v", x)
+
+		return
+	}
+
+	_, err := deep.Fprint(w, x)
+	if err != nil {
+		fmt.Fprintf(w, "PRINT ERROR: %v", err)
+	}
+}
+
+func writeDistance(w io.Writer, prefix string, d interface{}) {
+	if d == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "%s", prefix)
+	writeValue(w, d)
+	fmt.Fprintf(w, ")")
+}
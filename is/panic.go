@@ -0,0 +1,146 @@
+package is
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/nikandfor/assert/deep"
+)
+
+func Panics(f func()) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		_, panicked, _ := catch(f)
+		if panicked {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want panic")
+
+		return false
+	}), "panic")
+}
+
+// PanicsWithValue checks f panics with the value deep equal to v.
+func PanicsWithValue(f func(), v interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		p, panicked, stack := catch(f)
+		if !panicked {
+			fmt.Fprintf(w, "Want panic with value: %s", sprint(v))
+
+			return false
+		}
+
+		var buf bytes.Buffer
+
+		if deep.Diff(&buf, v, p) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Panic value: %s\nWant value:  %s\nDiff:\n%s%s", sprint(p), sprint(v), buf.Bytes(), stack)
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "panic with value %s", sprint(v))
+	})
+}
+
+// PanicsWithError checks f panics with an error matching target by errors.Is.
+// target must not be nil.
+func PanicsWithError(f func(), target error) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if target == nil {
+			fmt.Fprintf(w, "Target error must not be nil")
+
+			return false
+		}
+
+		p, panicked, stack := catch(f)
+		if !panicked {
+			fmt.Fprintf(w, "Want panic with error: %q (type %T)", target.Error(), target)
+
+			return false
+		}
+
+		err, ok := p.(error)
+		if !ok {
+			fmt.Fprintf(w, "Panic value is not an error: %s\n%s", sprint(p), stack)
+
+			return false
+		}
+
+		if errors.Is(err, target) {
+			return true
+		}
+
+		writeChain(w, err)
+
+		fmt.Fprintf(w, "is not %q (type %T)\n%s", target.Error(), target, stack)
+
+		return false
+	}), "panic with error %v", target)
+}
+
+func NotPanics(f func()) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		p, panicked, stack := catch(f)
+		if !panicked {
+			return true
+		}
+
+		fmt.Fprintf(w, "PANIC: %v\n%s", p, stack)
+
+		return false
+	}), "no panic")
+}
+
+func catch(f func()) (p interface{}, panicked bool, stack []byte) {
+	defer func() {
+		if !panicked {
+			return
+		}
+
+		p = recover()
+		stack = trimStack(debug.Stack(), runtime.FuncForPC(reflect.ValueOf(catch).Pointer()).Name())
+	}()
+
+	panicked = true
+
+	f()
+
+	panicked = false
+
+	return
+}
+
+// trimStack leaves only frames between the panic and the fname call.
+func trimStack(s []byte, fname string) []byte {
+	lines := bytes.SplitAfter(s, []byte("\n"))
+	if len(lines) == 0 {
+		return s
+	}
+
+	st, end := 1, len(lines)
+
+	for i, l := range lines {
+		if bytes.HasPrefix(l, []byte("panic(")) && st == 1 {
+			st = i + 2
+		}
+
+		if bytes.HasPrefix(l, []byte(fname+"(")) {
+			end = i
+
+			break
+		}
+	}
+
+	if st > end {
+		st = 1
+	}
+
+	return bytes.Join(append(lines[:1:1], lines[st:end]...), nil)
+}
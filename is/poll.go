@@ -0,0 +1,160 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+)
+
+type (
+	Clock interface {
+		Now() time.Time
+		Sleep(d time.Duration)
+	}
+
+	// Poll is a Checker which evaluates a Checker repeatedly until
+	// it's settled or the timeout is reached.
+	Poll struct {
+		f        func() Checker
+		mode     pollMode
+		timeout  time.Duration
+		tick     time.Duration
+		deadline time.Time
+		clock    Clock
+	}
+
+	// Deadliner is implemented by writers which know the test deadline,
+	// like the one passed by assert.Eval.
+	// Poll stops at it unless WithDeadline is set.
+	Deadliner interface {
+		Deadline() (time.Time, bool)
+	}
+
+	pollMode int
+
+	realClock struct{}
+)
+
+const (
+	pollEventually pollMode = iota
+	pollNever
+	pollConsistently
+)
+
+// Eventually checks the Checker returned by f passes at least once before timeout.
+// f is called each tick to get a fresh Checker.
+func Eventually(f func() Checker, timeout, tick time.Duration) *Poll {
+	return newPoll(f, pollEventually, timeout, tick)
+}
+
+// Never checks the Checker returned by f never passes during timeout.
+func Never(f func() Checker, timeout, tick time.Duration) *Poll {
+	return newPoll(f, pollNever, timeout, tick)
+}
+
+// Consistently checks the Checker returned by f passes every time during d.
+func Consistently(f func() Checker, d, tick time.Duration) *Poll {
+	return newPoll(f, pollConsistently, d, tick)
+}
+
+func newPoll(f func() Checker, mode pollMode, timeout, tick time.Duration) *Poll {
+	return &Poll{
+		f:       f,
+		mode:    mode,
+		timeout: timeout,
+		tick:    tick,
+		clock:   realClock{},
+	}
+}
+
+// WithClock sets the clock used to measure time and sleep between ticks.
+func (p *Poll) WithClock(c Clock) *Poll {
+	p.clock = c
+
+	return p
+}
+
+// WithDeadline stops polling at d if it's earlier than the timeout.
+// Never and Consistently fail if stopped early,
+// as the condition was not watched for the whole period.
+// If not set, the deadline of the writer is used if it's a Deadliner.
+func (p *Poll) WithDeadline(d time.Time) *Poll {
+	p.deadline = d
+
+	return p
+}
+
+func (p *Poll) Check(w io.Writer) bool {
+	start := p.clock.Now()
+
+	dl := p.deadline
+	if d, ok := w.(Deadliner); ok && dl.IsZero() {
+		dl, _ = d.Deadline()
+	}
+
+	end := start.Add(p.timeout)
+	truncated := !dl.IsZero() && dl.Before(end)
+
+	if truncated {
+		end = dl
+	}
+
+	var buf bytes.Buffer
+
+	for i := 1; ; i++ {
+		buf.Reset()
+
+		ok := p.f().Check(&buf)
+
+		switch {
+		case ok && p.mode == pollEventually:
+			return true
+		case ok && p.mode == pollNever:
+			fmt.Fprintf(w, "Condition passed after %v (attempt %d)", p.clock.Now().Sub(start), i)
+
+			return false
+		case !ok && p.mode == pollConsistently:
+			fmt.Fprintf(w, "Condition failed after %v (attempt %d):\n%s", p.clock.Now().Sub(start), i, buf.Bytes())
+
+			return false
+		}
+
+		if !p.clock.Now().Before(end) {
+			switch {
+			case p.mode != pollEventually && !truncated:
+				return true
+			case p.mode != pollEventually:
+				fmt.Fprintf(w, "Stopped at the test deadline after %v of %v (%d attempts)", p.clock.Now().Sub(start), p.timeout, i)
+
+				return false
+			case truncated:
+				fmt.Fprintf(w, "Condition not met in %v of %v, stopped at the test deadline (%d attempts), last failure:\n%s", p.clock.Now().Sub(start), p.timeout, i, buf.Bytes())
+
+				return false
+			}
+
+			fmt.Fprintf(w, "Condition not met in %v (%d attempts), last failure:\n%s", p.clock.Now().Sub(start), i, buf.Bytes())
+
+			return false
+		}
+
+		p.clock.Sleep(p.tick)
+	}
+}
+
+// Describe implements Describer.
+func (p *Poll) Describe(w io.Writer) {
+	switch p.mode {
+	case pollEventually:
+		fmt.Fprintf(w, "condition eventually passing within %v", p.timeout)
+	case pollNever:
+		fmt.Fprintf(w, "condition never passing during %v", p.timeout)
+	default:
+		fmt.Fprintf(w, "condition consistently passing during %v", p.timeout)
+	}
+}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
@@ -0,0 +1,328 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nikandfor/assert/deep"
+)
+
+type (
+	// mark is a labeled string aligned at byte offset i.
+	// Negative i aligns at -i and points the caret
+	// to the rune before it, as for suffixes.
+	mark struct {
+		label string
+		s     string
+		i     int
+	}
+)
+
+func HasPrefix(s, prefix string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+
+		i := deep.CommonPrefix(s, prefix)
+
+		fmt.Fprintf(w, "Want prefix%s:\n", argName(w, 0))
+		writeMarks(w, fmt.Sprintf("at rune %d", utf8.RuneCountInString(s[:i])), mark{"prefix", prefix, i}, mark{"string", s, i})
+
+		return false
+	}), "prefix %q", prefix)
+}
+
+func HasSuffix(s, suffix string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+
+		k := commonSuffix(s, suffix)
+
+		note := "string is shorter than suffix"
+		if k < len(s) {
+			note = fmt.Sprintf("at rune %d", utf8.RuneCountInString(s[:len(s)-k])-1)
+		}
+
+		fmt.Fprintf(w, "Want suffix%s:\n", argName(w, 0))
+		writeMarks(w, note, mark{"suffix", suffix, -(len(suffix) - k)}, mark{"string", s, -(len(s) - k)})
+
+		return false
+	}), "suffix %q", suffix)
+}
+
+// EqualFold checks strings are equal under Unicode case-folding.
+func EqualFold(exp, act string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if strings.EqualFold(exp, act) {
+			return true
+		}
+
+		i, j := foldPrefix(exp, act)
+
+		fmt.Fprintf(w, "Not equal ignoring case%s:\n", argName(w, 1))
+		writeMarks(w, fmt.Sprintf("at rune %d", utf8.RuneCountInString(act[:j])), mark{"expected", exp, i}, mark{"actual", act, j})
+
+		return false
+	}), "equal ignoring case to %q", exp)
+}
+
+// ContainsString checks s contains substr.
+// The longest partial match is shown on failure.
+func ContainsString(s, substr string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		if strings.Contains(s, substr) {
+			return true
+		}
+
+		var st, l int
+
+		for i := 0; i < len(s); {
+			if p := deep.CommonPrefix(s[i:], substr); p > l {
+				st, l = i, p
+			}
+
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+
+		note := "no partial match"
+		if l != 0 {
+			note = fmt.Sprintf("partial match %q at rune %d", s[st:st+l], utf8.RuneCountInString(s[:st]))
+		}
+
+		fmt.Fprintf(w, "Want substring%s:\n", argName(w, 0))
+		writeMarks(w, note, mark{"substr", substr, l}, mark{"string", s, st + l})
+
+		return false
+	}), "substring %q", substr)
+}
+
+// Regexp checks s matches re.
+// re is a string or *regexp.Regexp.
+// The longest part of s which could be a start of a match is shown on failure.
+func Regexp(re interface{}, s string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		rx, ok := compileRegexp(w, re)
+		if !ok {
+			return false
+		}
+
+		if rx.MatchString(s) {
+			return true
+		}
+
+		st, end := partialMatch(rx, s)
+
+		note := "no partial match"
+		if end != st {
+			note = fmt.Sprintf("partial match %q at rune %d", s[st:end], utf8.RuneCountInString(s[:st]))
+		}
+
+		fmt.Fprintf(w, "String%s does not match %q:\n", argName(w, 1), rx)
+		writeMarks(w, note, mark{"string", s, end})
+
+		return false
+	}), "matching %v", re)
+}
+
+// NotRegexp checks s doesn't match re.
+// re is a string or *regexp.Regexp.
+func NotRegexp(re interface{}, s string) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		rx, ok := compileRegexp(w, re)
+		if !ok {
+			return false
+		}
+
+		loc := rx.FindStringIndex(s)
+		if loc == nil {
+			return true
+		}
+
+		fmt.Fprintf(w, "String%s matches %q:\n", argName(w, 1), rx)
+		writeMarks(w, fmt.Sprintf("match %q at rune %d", s[loc[0]:loc[1]], utf8.RuneCountInString(s[:loc[0]])), mark{"string", s, loc[0]})
+
+		return false
+	}), "not matching %v", re)
+}
+
+// writeMarks writes quoted strings aligned by their marked offsets
+// and a caret under them followed by note.
+// Negative offset is counted from the end of the string and
+// the caret points to the rune before it.
+func writeMarks(w io.Writer, note string, ms ...mark) {
+	qs := make([]string, len(ms))
+	cols := make([]int, len(ms))
+
+	lw, col := 0, 0
+	back := false
+
+	for i, m := range ms {
+		at := m.i
+		if at < 0 {
+			at = -at
+			back = true
+		}
+
+		qs[i], cols[i] = deep.Snippet(m.s, at)
+
+		if len(m.label) > lw {
+			lw = len(m.label)
+		}
+
+		if cols[i] > col {
+			col = cols[i]
+		}
+	}
+
+	for i, m := range ms {
+		fmt.Fprintf(w, "%-*s %s%s\n", lw+1, m.label+":", strings.Repeat(" ", col-cols[i]), qs[i])
+	}
+
+	if back {
+		col--
+	}
+
+	fmt.Fprintf(w, "%s^ %s", strings.Repeat(" ", lw+2+col), note)
+}
+
+func commonSuffix(a, b string) (k int) {
+	for k < len(a) && k < len(b) {
+		ra, sa := utf8.DecodeLastRuneInString(a[:len(a)-k])
+		rb, sb := utf8.DecodeLastRuneInString(b[:len(b)-k])
+
+		if ra != rb || sa != sb {
+			break
+		}
+
+		k += sa
+	}
+
+	return k
+}
+
+func foldPrefix(a, b string) (i, j int) {
+	for i < len(a) && j < len(b) {
+		ra, sa := utf8.DecodeRuneInString(a[i:])
+		rb, sb := utf8.DecodeRuneInString(b[j:])
+
+		if !strings.EqualFold(string(ra), string(rb)) {
+			break
+		}
+
+		i += sa
+		j += sb
+	}
+
+	return i, j
+}
+
+// partialMatch finds the longest s[st:end] which is a prefix of some re match.
+func partialMatch(re *regexp.Regexp, s string) (st, end int) {
+	sre, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return 0, 0
+	}
+
+	prog, err := syntax.Compile(sre.Simplify())
+	if err != nil {
+		return 0, 0
+	}
+
+	type thread struct {
+		pc uint32
+		st int
+	}
+
+	seen := make([]int, len(prog.Inst))
+	gen := 1
+
+	var add func(l []thread, pc uint32, st int, ctx syntax.EmptyOp) []thread
+	add = func(l []thread, pc uint32, st int, ctx syntax.EmptyOp) []thread {
+		if seen[pc] == gen {
+			return l
+		}
+
+		seen[pc] = gen
+
+		in := &prog.Inst[pc]
+
+		switch in.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			l = add(l, in.Out, st, ctx)
+			return add(l, in.Arg, st, ctx)
+		case syntax.InstCapture, syntax.InstNop:
+			return add(l, in.Out, st, ctx)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(in.Arg)&^ctx != 0 {
+				return l
+			}
+
+			return add(l, in.Out, st, ctx)
+		case syntax.InstFail:
+			return l
+		}
+
+		return append(l, thread{pc: pc, st: st})
+	}
+
+	next := func(pos int) rune {
+		if pos == len(s) {
+			return -1
+		}
+
+		r, _ := utf8.DecodeRuneInString(s[pos:])
+
+		return r
+	}
+
+	cur := add(nil, uint32(prog.Start), 0, syntax.EmptyOpContext(-1, next(0)))
+	var nxt []thread
+
+	for pos := 0; pos < len(s) && len(cur) != 0; {
+		r, size := utf8.DecodeRuneInString(s[pos:])
+		npos := pos + size
+		ctx := syntax.EmptyOpContext(r, next(npos))
+
+		gen++
+		nxt = nxt[:0]
+
+		for _, t := range cur {
+			in := &prog.Inst[t.pc]
+
+			switch in.Op {
+			case syntax.InstRune, syntax.InstRune1:
+				if !in.MatchRune(r) {
+					continue
+				}
+			case syntax.InstRuneAny:
+			case syntax.InstRuneAnyNotNL:
+				if r == '\n' {
+					continue
+				}
+			default:
+				continue
+			}
+
+			if npos-t.st > end-st {
+				st, end = t.st, npos
+			}
+
+			nxt = add(nxt, in.Out, t.st, ctx)
+		}
+
+		nxt = add(nxt, uint32(prog.Start), npos, ctx)
+
+		cur, nxt = nxt, cur
+		pos = npos
+	}
+
+	return st, end
+}
@@ -0,0 +1,97 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// WithinDuration checks exp and act differ by no more than d.
+func WithinDuration(exp, act time.Time, d time.Duration) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		delta := act.Sub(exp)
+		if delta >= -d && delta <= d {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want times within %v, got delta %v:\n", d, delta)
+		writeTimes(w, "expected", exp, "actual", act)
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "time within %v of %v", d, exp.Format(time.RFC3339Nano))
+	})
+}
+
+// SameInstant checks exp and act are the same instant
+// regardless of location and monotonic clock reading.
+func SameInstant(exp, act time.Time) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		if exp.Equal(act) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want the same instant, got delta %v:\n", act.Sub(exp))
+		writeTimes(w, "expected", exp, "actual", act)
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "the same instant as %v", exp.Format(time.RFC3339Nano))
+	})
+}
+
+func TimeBefore(t, ref time.Time) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		if t.Before(ref) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want time before reference, got %v after:\n", t.Sub(ref))
+		writeTimes(w, "time", t, "reference", ref)
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "time before %v", ref.Format(time.RFC3339Nano))
+	})
+}
+
+func TimeAfter(t, ref time.Time) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		if t.After(ref) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want time after reference, got %v before:\n", ref.Sub(t))
+		writeTimes(w, "time", t, "reference", ref)
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "time after %v", ref.Format(time.RFC3339Nano))
+	})
+}
+
+// Truncated checks t is a multiple of unit since the zero time,
+// that is t.Truncate(unit) is the same instant.
+func Truncated(t time.Time, unit time.Duration) Checker {
+	return Described(CheckerFunc(func(w io.Writer) bool {
+		tr := t.Truncate(unit)
+		if tr.Equal(t) {
+			return true
+		}
+
+		fmt.Fprintf(w, "Want time truncated to %v, got %v extra:\n", unit, t.Sub(tr))
+		writeTimes(w, "time", t, "truncated", tr)
+
+		return false
+	}), "time truncated to %v", unit)
+}
+
+func writeTimes(w io.Writer, la string, a time.Time, lb string, b time.Time) {
+	l := len(la)
+	if len(lb) > l {
+		l = len(lb)
+	}
+
+	fmt.Fprintf(w, "%-*s %s\n", l+1, la+":", a.Format(time.RFC3339Nano))
+	fmt.Fprintf(w, "%-*s %s", l+1, lb+":", b.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,236 @@
+package is
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	xmlNode struct {
+		name     xml.Name
+		attrs    []xml.Attr
+		children []*xmlNode
+		text     string
+	}
+)
+
+// XMLEq checks exp and act are equivalent XML documents.
+// Attribute order, namespace prefixes, comments
+// and whitespace around text are ignored.
+// []byte, string and io.Reader arguments are parsed as XML,
+// other values are marshaled first.
+// Differences are reported with XPath-like paths.
+func XMLEq(exp, act interface{}) Checker {
+	return describe(CheckerFunc(func(w io.Writer) bool {
+		e, err := decodeXML(exp)
+		if err != nil {
+			fmt.Fprintf(w, "Expected%s: %v", argName(w, 0), err)
+
+			return false
+		}
+
+		a, err := decodeXML(act)
+		if err != nil {
+			fmt.Fprintf(w, "Actual%s: %v", argName(w, 1), err)
+
+			return false
+		}
+
+		var buf bytes.Buffer
+
+		if e.name != a.name {
+			fmt.Fprintf(&buf, "/: element %s != %s\n", xmlName(e.name), xmlName(a.name))
+		} else {
+			xmlEqual(&buf, "/"+e.name.Local, e, a)
+		}
+
+		if buf.Len() == 0 {
+			return true
+		}
+
+		fmt.Fprintf(w, "XML not equal:\n%s", buf.Bytes())
+
+		return false
+	}), func(w io.Writer) {
+		fmt.Fprintf(w, "XML equal to %s", docString(exp))
+	})
+}
+
+func decodeXML(x interface{}) (*xmlNode, error) {
+	var r io.Reader
+
+	switch x := x.(type) {
+	case []byte:
+		r = bytes.NewReader(x)
+	case string:
+		r = strings.NewReader(x)
+	case io.Reader:
+		r = x
+	default:
+		data, err := xml.Marshal(x)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %T: %w", x, err)
+		}
+
+		r = bytes.NewReader(data)
+	}
+
+	d := xml.NewDecoder(r)
+
+	var root *xmlNode
+	var stack []*xmlNode
+
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: tok.Name}
+
+			for _, a := range tok.Attr {
+				if a.Name.Space == "xmlns" || a.Name.Space == "" && a.Name.Local == "xmlns" {
+					continue
+				}
+
+				n.attrs = append(n.attrs, a)
+			}
+
+			sort.Slice(n.attrs, func(i, j int) bool {
+				return xmlName(n.attrs[i].Name) < xmlName(n.attrs[j].Name)
+			})
+
+			switch {
+			case len(stack) != 0:
+				p := stack[len(stack)-1]
+				p.children = append(p.children, n)
+			case root != nil:
+				return nil, errors.New("invalid XML: multiple root elements")
+			default:
+				root = n
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			s := strings.TrimSpace(string(tok))
+			if s == "" {
+				continue
+			}
+
+			if len(stack) == 0 {
+				return nil, errors.New("invalid XML: text outside of the root element")
+			}
+
+			p := stack[len(stack)-1]
+
+			if p.text != "" {
+				p.text += " "
+			}
+
+			p.text += s
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("invalid XML: no root element")
+	}
+
+	return root, nil
+}
+
+func xmlEqual(w io.Writer, path string, e, a *xmlNode) {
+	xmlAttrs(w, path, e.attrs, a.attrs)
+
+	if e.text != a.text {
+		fmt.Fprintf(w, "%s/text(): %q != %q\n", path, e.text, a.text)
+	}
+
+	child := func(l []*xmlNode, i int) string {
+		n, cnt := 0, 0
+
+		for j, c := range l {
+			if c.name != l[i].name {
+				continue
+			}
+
+			if j <= i {
+				n++
+			}
+
+			cnt++
+		}
+
+		p := path + "/" + l[i].name.Local
+		if cnt > 1 || n > 1 {
+			p += "[" + strconv.Itoa(n) + "]"
+		}
+
+		return p
+	}
+
+	for i := 0; i < len(e.children) || i < len(a.children); i++ {
+		switch {
+		case i >= len(a.children):
+			fmt.Fprintf(w, "%s: missing in actual: %s\n", child(e.children, i), xmlName(e.children[i].name))
+		case i >= len(e.children):
+			fmt.Fprintf(w, "%s: extra in actual: %s\n", child(a.children, i), xmlName(a.children[i].name))
+		case e.children[i].name != a.children[i].name:
+			fmt.Fprintf(w, "%s/*[%d]: element %s != %s\n", path, i+1, xmlName(e.children[i].name), xmlName(a.children[i].name))
+		default:
+			xmlEqual(w, child(e.children, i), e.children[i], a.children[i])
+		}
+	}
+}
+
+func xmlAttrs(w io.Writer, path string, e, a []xml.Attr) {
+	i, j := 0, 0
+
+	for i < len(e) || j < len(a) {
+		var en, an string
+
+		if i < len(e) {
+			en = xmlName(e[i].Name)
+		}
+
+		if j < len(a) {
+			an = xmlName(a[j].Name)
+		}
+
+		switch {
+		case j == len(a) || i < len(e) && en < an:
+			fmt.Fprintf(w, "%s/@%s: missing in actual: %q\n", path, e[i].Name.Local, e[i].Value)
+			i++
+		case i == len(e) || an < en:
+			fmt.Fprintf(w, "%s/@%s: extra in actual: %q\n", path, a[j].Name.Local, a[j].Value)
+			j++
+		default:
+			if e[i].Value != a[j].Value {
+				fmt.Fprintf(w, "%s/@%s: %q != %q\n", path, e[i].Name.Local, e[i].Value, a[j].Value)
+			}
+
+			i++
+			j++
+		}
+	}
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+
+	return "{" + n.Space + "}" + n.Local
+}
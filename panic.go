@@ -0,0 +1,35 @@
+package assert
+
+import "github.com/nikandfor/assert/is"
+
+func Panics(t TestingT, f func(), args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Panics(f), args...)
+}
+
+func PanicsWithValue(t TestingT, f func(), v interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.PanicsWithValue(f, v), args...)
+}
+
+func PanicsWithError(t TestingT, f func(), target error, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.PanicsWithError(f, target), args...)
+}
+
+func NotPanics(t TestingT, f func(), args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.NotPanics(f), args...)
+}
@@ -0,0 +1,42 @@
+package assert
+
+import (
+	"time"
+
+	"github.com/nikandfor/assert/is"
+)
+
+// deadlineMargin is left before the test deadline
+// to report the failure instead of being killed by the test timeout.
+// Eval passes the deadline to the checkers, so is.Poll stops in time.
+const deadlineMargin = time.Second
+
+func Eventually(t TestingT, cond func() bool, timeout, tick time.Duration, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Eventually(condChecker(cond), timeout, tick), args...)
+}
+
+func Never(t TestingT, cond func() bool, timeout, tick time.Duration, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Never(condChecker(cond), timeout, tick), args...)
+}
+
+func Consistently(t TestingT, cond func() bool, d, tick time.Duration, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Consistently(condChecker(cond), d, tick), args...)
+}
+
+func condChecker(cond func() bool) func() Checker {
+	return func() Checker {
+		return is.True(cond())
+	}
+}
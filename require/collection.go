@@ -0,0 +1,59 @@
+package require
+
+import "github.com/nikandfor/assert/is"
+
+func Contains(t TestingT, x, elem interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Contains(x, elem), args...)
+}
+
+func NotContains(t TestingT, x, elem interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotContains(x, elem), args...)
+}
+
+func Len(t TestingT, x interface{}, l int, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Len(x, l), args...)
+}
+
+func Empty(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Empty(x), args...)
+}
+
+func NotEmpty(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotEmpty(x), args...)
+}
+
+func ElementsMatch(t TestingT, exp, act interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ElementsMatch(exp, act), args...)
+}
+
+func Subset(t TestingT, list, sub interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Subset(list, sub), args...)
+}
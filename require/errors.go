@@ -0,0 +1,35 @@
+package require
+
+import "github.com/nikandfor/assert/is"
+
+func ErrorAs(t TestingT, err error, target interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ErrorAs(err, target), args...)
+}
+
+func ErrorContains(t TestingT, err error, substr string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ErrorContains(err, substr), args...)
+}
+
+func ErrorRegexp(t TestingT, err error, re interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ErrorRegexp(err, re), args...)
+}
+
+func ErrorType(t TestingT, err error, typ interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ErrorType(err, typ), args...)
+}
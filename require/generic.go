@@ -0,0 +1,79 @@
+package require
+
+import (
+	"cmp"
+
+	"github.com/nikandfor/assert/is"
+)
+
+func EqualOf[T any](t TestingT, exp, act T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.EqualOf(exp, act), args...)
+}
+
+func NotEqualOf[T any](t TestingT, exp, act T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotEqualOf(exp, act), args...)
+}
+
+func LessOf[T cmp.Ordered](t TestingT, a, b T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.LessOf(a, b), args...)
+}
+
+func GreaterOf[T cmp.Ordered](t TestingT, a, b T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.GreaterOf(a, b), args...)
+}
+
+func InRangeOf[T cmp.Ordered](t TestingT, x, lo, hi T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.InRangeOf(x, lo, hi), args...)
+}
+
+func LessOrEqualOf[T cmp.Ordered](t TestingT, a, b T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.LessOrEqualOf(a, b), args...)
+}
+
+func GreaterOrEqualOf[T cmp.Ordered](t TestingT, a, b T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.GreaterOrEqualOf(a, b), args...)
+}
+
+func PositiveOf[T cmp.Ordered](t TestingT, x T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.PositiveOf(x), args...)
+}
+
+func NegativeOf[T cmp.Ordered](t TestingT, x T, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NegativeOf(x), args...)
+}
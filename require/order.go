@@ -0,0 +1,75 @@
+package require
+
+import "github.com/nikandfor/assert/is"
+
+func Greater(t TestingT, a, b interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Greater(a, b), args...)
+}
+
+func GreaterOrEqual(t TestingT, a, b interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.GreaterOrEqual(a, b), args...)
+}
+
+func Less(t TestingT, a, b interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Less(a, b), args...)
+}
+
+func LessOrEqual(t TestingT, a, b interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.LessOrEqual(a, b), args...)
+}
+
+func Between(t TestingT, x, lo, hi interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Between(x, lo, hi), args...)
+}
+
+func Positive(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Positive(x), args...)
+}
+
+func Negative(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Negative(x), args...)
+}
+
+func Finite(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Finite(x), args...)
+}
+
+func NaN(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NaN(x), args...)
+}
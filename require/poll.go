@@ -0,0 +1,43 @@
+package require
+
+import (
+	"time"
+
+	"github.com/nikandfor/assert"
+)
+
+func Eventually(t TestingT, cond func() bool, timeout, tick time.Duration, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if assert.Eventually(t, cond, timeout, tick, args...) {
+		return
+	}
+
+	stop(t)
+}
+
+func Never(t TestingT, cond func() bool, timeout, tick time.Duration, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if assert.Never(t, cond, timeout, tick, args...) {
+		return
+	}
+
+	stop(t)
+}
+
+func Consistently(t TestingT, cond func() bool, d, tick time.Duration, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if assert.Consistently(t, cond, d, tick, args...) {
+		return
+	}
+
+	stop(t)
+}
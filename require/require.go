@@ -0,0 +1,169 @@
+package require
+
+import (
+	"github.com/nikandfor/assert"
+	"github.com/nikandfor/assert/deep"
+	"github.com/nikandfor/assert/is"
+)
+
+type (
+	TestingT = assert.TestingT
+
+	Checker = is.Checker
+
+	helper interface {
+		Helper()
+	}
+
+	failNow interface {
+		FailNow()
+	}
+)
+
+func Eval(t TestingT, c Checker, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if assert.Eval(t, c, args...) {
+		return
+	}
+
+	stop(t)
+}
+
+func Any(t TestingT, c []Checker, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if assert.Any(t, c, args...) {
+		return
+	}
+
+	stop(t)
+}
+
+func All(t TestingT, c []Checker, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if assert.All(t, c, args...) {
+		return
+	}
+
+	stop(t)
+}
+
+func Fail(t TestingT, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	assert.Fail(t, args...)
+
+	stop(t)
+}
+
+func True(t TestingT, ok bool, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.True(ok), args...)
+}
+
+func False(t TestingT, ok bool, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.False(ok), args...)
+}
+
+func Nil(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Nil(x), args...)
+}
+
+func NotNil(t TestingT, x interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotNil(x), args...)
+}
+
+func NoError(t TestingT, err error, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NoError(err), args...)
+}
+
+func Error(t TestingT, err error, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Error(err), args...)
+}
+
+func ErrorIs(t TestingT, err, target error, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ErrorIs(err, target), args...)
+}
+
+func Equal(t TestingT, exp, act interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Equal(exp, act), args...)
+}
+
+func EqualWith(t TestingT, exp, act interface{}, opts []deep.Option, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.EqualWith(exp, act, opts...), args...)
+}
+
+func NotEqual(t TestingT, exp, act interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotEqual(exp, act), args...)
+}
+
+func Zero(t TestingT, val interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Zero(val), args...)
+}
+
+func NotZero(t TestingT, val interface{}, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotZero(val), args...)
+}
+
+func stop(t TestingT) {
+	if t, ok := t.(failNow); ok {
+		t.FailNow()
+	}
+}
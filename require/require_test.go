@@ -0,0 +1,51 @@
+package require_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nikandfor/assert/require"
+)
+
+type (
+	TestT struct {
+		failed int
+		b      []byte
+	}
+)
+
+func TestNoError(t *testing.T) {
+	tt := &TestT{}
+
+	require.NoError(tt, nil)
+	if tt.failed != 0 || len(tt.b) != 0 {
+		t.Errorf("failed: %v\n%s", tt.failed, tt.b)
+	}
+
+	require.NoError(tt, errors.New("test_error"))
+	if tt.failed != 2 || len(tt.b) == 0 {
+		t.Errorf("failed: %v\n%s", tt.failed, tt.b)
+	}
+}
+
+func TestEqualOf(t *testing.T) {
+	tt := &TestT{}
+
+	require.EqualOf(tt, "a", "b")
+	if tt.failed != 2 || len(tt.b) == 0 {
+		t.Errorf("failed: %v\n%s", tt.failed, tt.b)
+	}
+}
+
+func (tt *TestT) Fail() {
+	tt.failed = 1
+}
+
+func (tt *TestT) FailNow() {
+	tt.failed = 2
+}
+
+func (tt *TestT) Logf(format string, args ...interface{}) {
+	tt.b = fmt.Appendf(tt.b, format, args...)
+}
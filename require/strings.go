@@ -0,0 +1,51 @@
+package require
+
+import "github.com/nikandfor/assert/is"
+
+func HasPrefix(t TestingT, s, prefix string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.HasPrefix(s, prefix), args...)
+}
+
+func HasSuffix(t TestingT, s, suffix string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.HasSuffix(s, suffix), args...)
+}
+
+func EqualFold(t TestingT, exp, act string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.EqualFold(exp, act), args...)
+}
+
+func ContainsString(t TestingT, s, substr string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.ContainsString(s, substr), args...)
+}
+
+func Regexp(t TestingT, re interface{}, s string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Regexp(re, s), args...)
+}
+
+func NotRegexp(t TestingT, re interface{}, s string, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.NotRegexp(re, s), args...)
+}
@@ -0,0 +1,47 @@
+package require
+
+import (
+	"time"
+
+	"github.com/nikandfor/assert/is"
+)
+
+func WithinDuration(t TestingT, exp, act time.Time, d time.Duration, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.WithinDuration(exp, act, d), args...)
+}
+
+func SameInstant(t TestingT, exp, act time.Time, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.SameInstant(exp, act), args...)
+}
+
+func TimeBefore(t TestingT, tm, ref time.Time, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.TimeBefore(tm, ref), args...)
+}
+
+func TimeAfter(t TestingT, tm, ref time.Time, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.TimeAfter(tm, ref), args...)
+}
+
+func Truncated(t TestingT, tm time.Time, unit time.Duration, args ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	Eval(t, is.Truncated(tm, unit), args...)
+}
@@ -0,0 +1,441 @@
+// Package snapshot compares values with snapshots stored next to the test file
+// in __snapshots__/<file>.snap.
+//
+// Snapshots are named by the test name and the Match call number within the test.
+// They are created and rewritten in golden.Update mode.
+// Snapshots of the test which are not matched by the end of the test are obsolete,
+// they are reported and removed in update mode.
+// Snapshots of tests which no longer exist are found by Run called from TestMain.
+//
+// Content lines starting with the header prefix or a backslash
+// are escaped with a backslash in the file.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nikandfor/assert"
+	"github.com/nikandfor/assert/deep"
+	"github.com/nikandfor/assert/golden"
+	"github.com/nikandfor/assert/is"
+)
+
+type (
+	TestingT = assert.TestingT
+
+	helper interface {
+		Helper()
+	}
+
+	namer interface {
+		Name() string
+	}
+
+	cleaner interface {
+		Cleanup(func())
+	}
+
+	logger interface {
+		Logf(string, ...interface{})
+	}
+
+	file struct {
+		path string
+
+		names []string
+		snaps map[string]string
+
+		used    map[string]bool
+		matched map[string]bool
+		counter map[string]int
+		tests   map[string]bool
+
+		dirty bool
+	}
+)
+
+const header = "--- "
+
+var (
+	mu    sync.Mutex
+	files = map[string]*file{}
+)
+
+// Match compares deep.GoSyntax representation of v
+// with the next snapshot of the test.
+// Values are printed with deep.Full, so the output is complete and reproducible.
+func Match(t TestingT, v interface{}, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	_, src, _, ok := runtime.Caller(1)
+	if !ok {
+		assert.Fail(t, "Snapshot: can't get caller file")
+
+		return false
+	}
+
+	var b bytes.Buffer
+
+	_, err := deep.FprintWith(&b, v, deep.GoSyntax(), deep.Full())
+	if err != nil {
+		assert.Fail(t, "Print value: %v", err)
+
+		return false
+	}
+
+	b.WriteByte('\n')
+
+	var test string
+	if t, ok := t.(namer); ok {
+		test = t.Name()
+	}
+
+	return assert.Eval(t, check(t, Path(src), test, b.String()), args...)
+}
+
+// Path returns snapshot file path for the test source file.
+func Path(src string) string {
+	return filepath.Join(filepath.Dir(src), "__snapshots__", strings.TrimSuffix(filepath.Base(src), ".go")+".snap")
+}
+
+func check(t TestingT, path, test, act string) is.Checker {
+	return is.CheckerFunc(func(w io.Writer) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		f, err := open(path)
+		if err != nil {
+			fmt.Fprintf(w, "Read snapshots: %v", err)
+
+			return false
+		}
+
+		c, cleanup := t.(cleaner)
+		if cleanup && !f.tests[test] {
+			f.tests[test] = true
+
+			c.Cleanup(func() { f.cleanup(t, test) })
+		}
+
+		f.counter[test]++
+		name := test + " " + strconv.Itoa(f.counter[test])
+
+		f.used[name] = true
+		f.matched[name] = true
+
+		exp, ok := f.snaps[name]
+
+		if golden.Update() {
+			if !ok || exp != act {
+				f.set(name, act)
+			}
+
+			if !cleanup && f.dirty {
+				err = f.write()
+			}
+			if err != nil {
+				fmt.Fprintf(w, "Write snapshots: %v", err)
+
+				return false
+			}
+
+			return true
+		}
+
+		if !ok {
+			fmt.Fprintf(w, "Snapshot %q does not exist, run with -assert.update to create it", name)
+
+			return false
+		}
+
+		if exp == act {
+			return true
+		}
+
+		fmt.Fprintf(w, "Snapshot %q differs, run with -assert.update to rewrite it\n", name)
+
+		deep.Diff(w, exp, act)
+
+		return false
+	})
+}
+
+func open(path string) (*file, error) {
+	if f, ok := files[path]; ok {
+		return f, nil
+	}
+
+	f := &file{
+		path:    path,
+		snaps:   map[string]string{},
+		used:    map[string]bool{},
+		matched: map[string]bool{},
+		counter: map[string]int{},
+		tests:   map[string]bool{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	err = f.parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	files[path] = f
+
+	return f, nil
+}
+
+func (f *file) parse(data []byte) error {
+	var name string
+	var b strings.Builder
+
+	flush := func() {
+		if name != "" {
+			f.set(name, b.String())
+		}
+
+		b.Reset()
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+
+	for line := 1; s.Scan(); line++ {
+		l := s.Text()
+
+		if strings.HasPrefix(l, header) {
+			flush()
+			name = l[len(header):]
+
+			continue
+		}
+
+		if name == "" {
+			return fmt.Errorf("line %d: content before snapshot header", line)
+		}
+
+		l = strings.TrimPrefix(l, `\`)
+
+		b.WriteString(l)
+		b.WriteByte('\n')
+	}
+
+	flush()
+
+	f.dirty = false
+
+	return s.Err()
+}
+
+func (f *file) set(name, val string) {
+	if _, ok := f.snaps[name]; !ok {
+		f.names = append(f.names, name)
+	}
+
+	f.snaps[name] = val
+	f.dirty = true
+}
+
+// cleanup reports obsolete snapshots of the finished test
+// and writes the file if anything was changed.
+// Tests without Cleanup method can't be checked for obsolete snapshots.
+func (f *file) cleanup(t TestingT, test string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var obsolete []string
+	names := f.names[:0]
+
+	for _, name := range f.names {
+		if !strings.HasPrefix(name, test+" ") || f.used[name] {
+			names = append(names, name)
+			continue
+		}
+
+		obsolete = append(obsolete, name)
+
+		if golden.Update() {
+			delete(f.snaps, name)
+			f.dirty = true
+		} else {
+			names = append(names, name)
+		}
+	}
+
+	f.names = names
+
+	for name := range f.used {
+		if strings.HasPrefix(name, test+" ") {
+			delete(f.used, name)
+		}
+	}
+
+	delete(f.counter, test)
+	delete(f.tests, test)
+
+	if l, ok := t.(logger); ok && len(obsolete) != 0 && !golden.Update() {
+		l.Logf("Obsolete snapshots in %s, run with -assert.update to remove them: %q", f.path, obsolete)
+	}
+
+	if !f.dirty {
+		return
+	}
+
+	err := f.write()
+	if err != nil {
+		assert.Fail(t, "Write snapshots: %v", err)
+	}
+}
+
+// Run runs the tests and then reports snapshots in __snapshots__ directory
+// which were not matched by any test, removing them in update mode.
+// Only done if all the tests were run and passed,
+// so tests skipped with t.Skip may have their snapshots reported.
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(snapshot.Run(m))
+//	}
+func Run(m interface{ Run() int }) int {
+	code := m.Run()
+	if code != 0 || filtered() {
+		return code
+	}
+
+	paths, err := filepath.Glob(filepath.Join("__snapshots__", "*.snap"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+
+		return 1
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, path := range paths {
+		path, err = filepath.Abs(path)
+		if err == nil {
+			err = sweep(path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+
+			code = 1
+		}
+	}
+
+	return code
+}
+
+func sweep(path string) error {
+	f, err := open(path)
+	if err != nil {
+		return err
+	}
+
+	var obsolete []string
+	names := f.names[:0]
+
+	for _, name := range f.names {
+		if f.matched[name] {
+			names = append(names, name)
+			continue
+		}
+
+		obsolete = append(obsolete, name)
+
+		if golden.Update() {
+			delete(f.snaps, name)
+			f.dirty = true
+		} else {
+			names = append(names, name)
+		}
+	}
+
+	f.names = names
+
+	if len(obsolete) != 0 && !golden.Update() {
+		fmt.Fprintf(os.Stderr, "Obsolete snapshots in %s, run with -assert.update to remove them: %q\n", f.path, obsolete)
+	}
+
+	if !f.dirty {
+		return nil
+	}
+
+	return f.write()
+}
+
+// filtered reports whether some tests were not run because of -run or -skip flags.
+func filtered() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if fl := flag.Lookup(name); fl != nil && fl.Value.String() != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *file) write() error {
+	if len(f.names) == 0 {
+		err := os.Remove(f.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+
+		f.dirty = err != nil
+
+		return err
+	}
+
+	var b bytes.Buffer
+
+	for _, name := range f.names {
+		b.WriteString(header)
+		b.WriteString(name)
+		b.WriteByte('\n')
+
+		for _, l := range strings.SplitAfter(f.snaps[name], "\n") {
+			if strings.HasPrefix(l, header) || strings.HasPrefix(l, `\`) {
+				b.WriteByte('\\')
+			}
+
+			b.WriteString(l)
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(f.path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(f.path, b.Bytes(), 0o644)
+	if err != nil {
+		return err
+	}
+
+	f.dirty = false
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nikandfor/assert"
+)
+
+type (
+	TestT struct {
+		name    string
+		failed  bool
+		b       []byte
+		cleanup []func()
+	}
+
+	point struct {
+		X, Y int
+		Tags map[string]bool
+	}
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(Run(m))
+}
+
+func TestMatch(t *testing.T) {
+	Match(t, point{X: 1, Y: 2, Tags: map[string]bool{"b": true, "a": false}})
+	Match(t, []string{"first", "second"})
+}
+
+func TestSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "__snapshots__", "x_test.snap")
+
+	run := func(update bool, vals ...string) *TestT {
+		tt := &TestT{name: "TestName"}
+
+		t.Setenv("ASSERT_UPDATE", strconv.FormatBool(update))
+
+		for _, v := range vals {
+			assert.Eval(tt, check(tt, path, tt.Name(), v+"\n"))
+		}
+
+		for i := len(tt.cleanup) - 1; i >= 0; i-- {
+			tt.cleanup[i]()
+		}
+
+		return tt
+	}
+
+	if tt := run(false, "a"); !tt.failed || !strings.Contains(string(tt.b), "does not exist") {
+		t.Errorf("missing: %v %s", tt.failed, tt.b)
+	}
+
+	if tt := run(true, "a", "b", "c"); tt.failed {
+		t.Errorf("update: %s", tt.b)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if exp := "--- TestName 1\na\n--- TestName 2\nb\n--- TestName 3\nc\n"; string(data) != exp {
+		t.Errorf("file:\n%s\nexpected:\n%s", data, exp)
+	}
+
+	if tt := run(false, "a", "x"); !tt.failed || !strings.Contains(string(tt.b), `Snapshot "TestName 2" differs`) ||
+		!strings.Contains(string(tt.b), `Obsolete snapshots in `+path+`, run with -assert.update to remove them: ["TestName 3"]`) {
+		t.Errorf("differs: %v %s", tt.failed, tt.b)
+	}
+
+	if tt := run(true, "a", "x"); tt.failed {
+		t.Errorf("update: %s", tt.b)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if exp := "--- TestName 1\na\n--- TestName 2\nx\n"; string(data) != exp {
+		t.Errorf("file:\n%s\nexpected:\n%s", data, exp)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "__snapshots__", "x_test.snap")
+
+	t.Setenv("ASSERT_UPDATE", "true")
+
+	tt := &TestT{name: "TestName"}
+
+	assert.Eval(tt, check(tt, path, tt.Name(), "--- a\n\\b\nc\n"))
+
+	for _, f := range tt.cleanup {
+		f()
+	}
+
+	if tt.failed {
+		t.Errorf("update: %s", tt.b)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if exp := "--- TestName 1\n\\--- a\n\\\\b\nc\n"; string(data) != exp {
+		t.Errorf("file:\n%s\nexpected:\n%s", data, exp)
+	}
+
+	delete(files, path)
+
+	f, err := open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	if exp := "--- a\n\\b\nc\n"; f.snaps["TestName 1"] != exp {
+		t.Errorf("parsed: %q, expected %q", f.snaps["TestName 1"], exp)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "__snapshots__", "x_test.snap")
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	err = os.WriteFile(path, []byte("--- TestGone 1\na\n"), 0o644)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	t.Setenv("ASSERT_UPDATE", "true")
+
+	err = sweep(path)
+	if err != nil {
+		t.Errorf("sweep: %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("obsolete snapshot file is not removed: %v", err)
+	}
+}
+
+func (tt *TestT) Name() string { return tt.name }
+
+func (tt *TestT) Fail() { tt.failed = true }
+
+func (tt *TestT) Cleanup(f func()) { tt.cleanup = append(tt.cleanup, f) }
+
+func (tt *TestT) Logf(format string, args ...interface{}) {
+	tt.b = append(tt.b, fmt.Sprintf(format, args...)...)
+	tt.b = append(tt.b, '\n')
+}
@@ -0,0 +1,245 @@
+package source
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+type (
+	// Arg is a source expression of a call argument.
+	Arg struct {
+		Expr string // empty for literals
+		Args []Arg  // arguments if Expr is a call
+	}
+
+	file struct {
+		fset *token.FileSet
+		f    *ast.File
+		src  []byte
+	}
+)
+
+var (
+	mu    sync.Mutex
+	cache = map[string]*file{}
+
+	libs []string
+)
+
+func init() {
+	root := path.Dir(reflect.TypeOf(file{}).PkgPath())
+
+	for _, p := range []string{"", "/is", "/require", "/source"} {
+		libs = append(libs, root+p+".")
+	}
+}
+
+// AssertionArgs finds the assertion call the library was entered from
+// and returns source expressions of its arguments following testing.T.
+// Literal arguments have empty Expr.
+// nil is returned if the source is not available.
+func AssertionArgs() []Arg {
+	var pcs [64]uintptr
+
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var last string
+
+	for {
+		fr, more := frames.Next()
+
+		if isLib(fr.Function) {
+			last = fr.Function
+		} else if last != "" {
+			return callArgs(fr.File, fr.Line, funcName(last))
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+func callArgs(name string, line int, fname string) (args []Arg) {
+	f := parse(name)
+	if f == nil {
+		return nil
+	}
+
+	var calls []*ast.CallExpr
+
+	ast.Inspect(f.f, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+
+		if f.fset.Position(n.Pos()).Line > line || f.fset.Position(n.End()).Line < line {
+			return false
+		}
+
+		c, ok := n.(*ast.CallExpr)
+		if ok && calleeName(c.Fun) == fname && len(c.Args) != 0 {
+			calls = append(calls, c)
+		}
+
+		return true
+	})
+
+	// Frames have no column, so we can't tell which of the calls on the line failed.
+	if len(calls) != 1 {
+		return nil
+	}
+
+	call := calls[0]
+
+	as := call.Args[1:]
+
+	switch fname {
+	case "Eval":
+		// Names are only known for the checker constructed in place.
+		c, ok := first(as).(*ast.CallExpr)
+		if !ok {
+			return nil
+		}
+
+		as = c.Args
+	case "Any", "All":
+		// Any and All pass the checkers to is.Or and is.AllOf.
+		l, ok := first(as).(*ast.CompositeLit)
+		if !ok {
+			return nil
+		}
+
+		as = l.Elts
+	}
+
+	return f.args(as)
+}
+
+func first(as []ast.Expr) ast.Expr {
+	if len(as) == 0 {
+		return nil
+	}
+
+	return as[0]
+}
+
+func (f *file) args(as []ast.Expr) (args []Arg) {
+	for _, a := range as {
+		arg := Arg{Expr: f.expr(a)}
+
+		if c, ok := a.(*ast.CallExpr); ok {
+			arg.Args = f.args(c.Args)
+		}
+
+		args = append(args, arg)
+	}
+
+	return args
+}
+
+func (f *file) expr(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.BasicLit, *ast.CompositeLit:
+		return ""
+	case *ast.Ident:
+		if e.Name == "nil" || e.Name == "true" || e.Name == "false" {
+			return ""
+		}
+	}
+
+	st := f.fset.Position(e.Pos()).Offset
+	end := f.fset.Position(e.End()).Offset
+
+	return string(f.src[st:end])
+}
+
+func parse(name string) *file {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if f, ok := cache[name]; ok {
+		return f
+	}
+
+	cache[name] = nil
+
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+
+	af, err := parser.ParseFile(fset, name, src, 0)
+	if err != nil {
+		return nil
+	}
+
+	f := &file{
+		fset: fset,
+		f:    af,
+		src:  src,
+	}
+
+	cache[name] = f
+
+	return f
+}
+
+func calleeName(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return calleeName(e.X)
+	case *ast.IndexListExpr:
+		return calleeName(e.X)
+	}
+
+	return ""
+}
+
+// funcName returns the name of the top level function or method
+// from the frame function name like pkg/path.(*T).Name[...].func1.
+func funcName(f string) string {
+	if p := strings.LastIndexByte(f, '/'); p != -1 {
+		f = f[p+1:]
+	}
+
+	if p := strings.IndexByte(f, '.'); p != -1 {
+		f = f[p+1:]
+	}
+
+	if strings.HasPrefix(f, "(") {
+		if p := strings.Index(f, ")."); p != -1 {
+			f = f[p+2:]
+		}
+	}
+
+	if p := strings.IndexAny(f, ".["); p != -1 {
+		f = f[:p]
+	}
+
+	return f
+}
+
+func isLib(f string) bool {
+	for _, l := range libs {
+		if strings.HasPrefix(f, l) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"time"
+
+	"github.com/nikandfor/assert/is"
+)
+
+func WithinDuration(t TestingT, exp, act time.Time, d time.Duration, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.WithinDuration(exp, act, d), args...)
+}
+
+func SameInstant(t TestingT, exp, act time.Time, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.SameInstant(exp, act), args...)
+}
+
+func TimeBefore(t TestingT, tm, ref time.Time, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.TimeBefore(tm, ref), args...)
+}
+
+func TimeAfter(t TestingT, tm, ref time.Time, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.TimeAfter(tm, ref), args...)
+}
+
+func Truncated(t TestingT, tm time.Time, unit time.Duration, args ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return Eval(t, is.Truncated(tm, unit), args...)
+}